@@ -17,6 +17,12 @@ type AdditionTask struct {
 	// Base is the base of the input numbers.
 	// For instance, base 10 is decimal.
 	Base int
+
+	// Feedback, if set, makes the task autoregressive.
+	// While the model outputs the sum, each input contains
+	// the previous digit of the sum, as given by
+	// FeedbackInput.
+	Feedback bool
 }
 
 // InputSize returns the number of input symbols, which
@@ -67,6 +73,9 @@ func (a *AdditionTask) NewSamples(n int) sgd.SampleSet {
 			sum := (x + y + carry) % a.Base
 			carry = (x + y + carry) / a.Base
 			inVec := make(linalg.Vector, a.Base+1)
+			if a.Feedback && j > 0 {
+				inVec = a.FeedbackInput(sample.Outputs[len(sample.Outputs)-1])
+			}
 			outVec := make(linalg.Vector, a.Base)
 			outVec[sum] = 1
 			sample.Inputs = append(sample.Inputs, inVec)
@@ -78,16 +87,33 @@ func (a *AdditionTask) NewSamples(n int) sgd.SampleSet {
 }
 
 func (a *AdditionTask) Score(model Model, batchSize, batchCount int) float64 {
-	return roundedBinaryTailScore(a, model, batchSize, batchCount, func(s []linalg.Vector) int {
-		var seenBefore bool
-		for i, x := range s {
-			if x[len(x)-1] == 1 {
-				if seenBefore {
-					return i + 1
-				}
-				seenBefore = true
+	return roundedBinaryTailScore(a, model, batchSize, batchCount, a.TailStart)
+}
+
+// TailStart returns the index of the first timestep at
+// which the model must output the sum.
+func (a *AdditionTask) TailStart(inputs []linalg.Vector) int {
+	var seenBefore bool
+	for i, x := range inputs {
+		if x[len(x)-1] == 1 {
+			if seenBefore {
+				return i + 1
 			}
+			seenBefore = true
 		}
-		panic("no tail found")
-	})
+	}
+	panic("no tail found")
+}
+
+// FeedbackInput returns an input containing the digit
+// with the largest output.
+func (a *AdditionTask) FeedbackInput(output linalg.Vector) linalg.Vector {
+	res := make(linalg.Vector, a.InputSize())
+	res[maxIdx(output)] = 1
+	return res
+}
+
+// Autoregressive returns a.Feedback.
+func (a *AdditionTask) Autoregressive() bool {
+	return a.Feedback
 }
@@ -0,0 +1,133 @@
+package seqtasks
+
+import (
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+)
+
+// An AutoregressiveTask is a Task in which the model's
+// outputs at the end of a sequence can be fed back to it as
+// inputs.
+//
+// The samples from an AutoregressiveTask are teacher
+// forced, meaning that each input in the tail is derived
+// from the expected output at the previous timestep.
+// The first input in the tail does not depend on any
+// output.
+type AutoregressiveTask interface {
+	Task
+
+	// TailStart returns the index of the first timestep in
+	// the tail of an input sequence.
+	TailStart(inputs []linalg.Vector) int
+
+	// FeedbackInput converts an output from one timestep in
+	// the tail to the input for the next timestep.
+	FeedbackInput(output linalg.Vector) linalg.Vector
+
+	// Autoregressive reports whether the task's samples
+	// actually feed outputs back as inputs.
+	// Tasks which can be configured either way return false
+	// when feedback is disabled, in which case free-running
+	// evaluation is meaningless.
+	Autoregressive() bool
+}
+
+// FreeRunningTask wraps an AutoregressiveTask so that it is
+// scored in free-running mode.
+//
+// Training samples are teacher forced, just like the
+// underlying task's samples.
+// During scoring, the model's own outputs are fed back as
+// inputs throughout the tail, so that any mistake affects
+// later timesteps.
+// Comparing this task's score to the underlying task's
+// score measures the effects of exposure bias.
+type FreeRunningTask struct {
+	Task AutoregressiveTask
+}
+
+// InputSize returns the underlying task's input size.
+func (f *FreeRunningTask) InputSize() int {
+	return f.Task.InputSize()
+}
+
+// OutputSize returns the underlying task's output size.
+func (f *FreeRunningTask) OutputSize() int {
+	return f.Task.OutputSize()
+}
+
+// NewSamples creates teacher-forced samples from the
+// underlying task.
+func (f *FreeRunningTask) NewSamples(n int) sgd.SampleSet {
+	return f.Task.NewSamples(n)
+}
+
+// Score scores the model using the underlying task's
+// scoring method, but with free-running tails.
+// It panics if the underlying task is not autoregressive.
+func (f *FreeRunningTask) Score(m Model, batchSize, batchCount int) float64 {
+	checkAutoregressive(f.Task)
+	return f.Task.Score(&freeRunningModel{Model: m, Task: f.Task}, batchSize, batchCount)
+}
+
+// ExposureBias scores a model on an AutoregressiveTask both
+// with teacher forcing and in free-running mode.
+// It panics if the task is not autoregressive.
+func ExposureBias(t AutoregressiveTask, m Model, batchSize,
+	batchCount int) (teacherForced, freeRunning float64) {
+	checkAutoregressive(t)
+	teacherForced = t.Score(m, batchSize, batchCount)
+	freeRunning = (&FreeRunningTask{Task: t}).Score(m, batchSize, batchCount)
+	return
+}
+
+func checkAutoregressive(t AutoregressiveTask) {
+	if !t.Autoregressive() {
+		panic("free-running evaluation requires an autoregressive task " +
+			"(is Feedback set?)")
+	}
+}
+
+// A freeRunningModel runs a model on an
+// AutoregressiveTask's input sequences, replacing the
+// teacher-forced tail inputs with the model's own
+// outputs.
+//
+// Since a Model cannot be run one timestep at a time, the
+// model is re-run on each sequence for every timestep in
+// the tail.
+type freeRunningModel struct {
+	Model
+	Task AutoregressiveTask
+}
+
+func (f *freeRunningModel) Run(inputs [][]linalg.Vector) [][]linalg.Vector {
+	seqs := make([][]linalg.Vector, len(inputs))
+	for i, seq := range inputs {
+		prefixLen := f.Task.TailStart(seq) + 1
+		if prefixLen > len(seq) {
+			prefixLen = len(seq)
+		}
+		seqs[i] = append([]linalg.Vector{}, seq[:prefixLen]...)
+	}
+	for {
+		var pending []int
+		var batch [][]linalg.Vector
+		for i, seq := range seqs {
+			if len(seq) < len(inputs[i]) {
+				pending = append(pending, i)
+				batch = append(batch, seq)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		outs := f.Model.Run(batch)
+		for j, i := range pending {
+			lastOut := outs[j][len(outs[j])-1]
+			seqs[i] = append(seqs[i], f.Task.FeedbackInput(lastOut))
+		}
+	}
+	return f.Model.Run(seqs)
+}
@@ -0,0 +1,114 @@
+package seqtasks
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// BanditEnvironment is a multi-armed bandit meta-learning
+// environment.
+//
+// At the start of each episode, every arm is assigned a
+// random payout probability between 0 and 1.
+// At each timestep, the model pulls the arm corresponding to
+// its largest output, and the arm pays out 1 or 0.
+// The model is not told the payout probabilities, so it
+// must learn to explore the arms and then exploit the best
+// one, all within a single episode.
+//
+// Each input gives the previously pulled arm as a one-hot
+// vector, followed by the previous payout.
+// The first input of an episode is all zeroes.
+//
+// The reward for each pull is the payout divided by the
+// number of pulls, so the total reward for an episode is
+// between 0 and 1.
+type BanditEnvironment struct {
+	// ArmCount is the number of arms.
+	ArmCount int
+
+	// Pulls is the number of pulls per episode.
+	Pulls int
+
+	probs    []float64
+	counts   []int
+	payouts  []float64
+	prevArm  int
+	prevPaid bool
+	step     int
+}
+
+// InputSize returns one more than the number of arms, since
+// each input contains an arm and a payout.
+func (b *BanditEnvironment) InputSize() int {
+	return b.ArmCount + 1
+}
+
+// OutputSize returns the number of arms.
+func (b *BanditEnvironment) OutputSize() int {
+	return b.ArmCount
+}
+
+// Reset starts a new episode with new payout
+// probabilities.
+func (b *BanditEnvironment) Reset() {
+	b.probs = make([]float64, b.ArmCount)
+	for i := range b.probs {
+		b.probs[i] = rand.Float64()
+	}
+	b.counts = make([]int, b.ArmCount)
+	b.payouts = make([]float64, b.ArmCount)
+	b.prevArm = -1
+	b.prevPaid = false
+	b.step = 0
+}
+
+// Observe returns the previous arm and payout.
+func (b *BanditEnvironment) Observe() linalg.Vector {
+	res := make(linalg.Vector, b.InputSize())
+	if b.prevArm >= 0 {
+		res[b.prevArm] = 1
+	}
+	if b.prevPaid {
+		res[b.ArmCount] = 1
+	}
+	return res
+}
+
+// Target returns the arm chosen by the UCB1 algorithm,
+// based on the pulls so far in the episode.
+func (b *BanditEnvironment) Target() linalg.Vector {
+	bestArm := -1
+	var bestValue float64
+	for arm, count := range b.counts {
+		if count == 0 {
+			bestArm = arm
+			break
+		}
+		value := b.payouts[arm]/float64(count) +
+			math.Sqrt(2*math.Log(float64(b.step))/float64(count))
+		if bestArm < 0 || value > bestValue {
+			bestArm = arm
+			bestValue = value
+		}
+	}
+	res := make(linalg.Vector, b.OutputSize())
+	res[bestArm] = 1
+	return res
+}
+
+// Step pulls the arm with the largest output.
+func (b *BanditEnvironment) Step(output linalg.Vector) (reward float64, done bool) {
+	arm := maxIdx(output)
+	b.prevArm = arm
+	b.prevPaid = rand.Float64() < b.probs[arm]
+	b.counts[arm]++
+	if b.prevPaid {
+		b.payouts[arm]++
+		reward = 1 / float64(b.Pulls)
+	}
+	b.step++
+	return reward, b.step == b.Pulls
+}
@@ -0,0 +1,104 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// CellularAutomatonTask requires the model to simulate an
+// elementary cellular automaton.
+//
+// The model is fed a random row of cells one cell at a time,
+// followed by a delimiter.
+// After the delimiter, the model must output the row which
+// results from running the automaton for a certain number
+// of generations, one cell at a time.
+type CellularAutomatonTask struct {
+	// Rule is the Wolfram code of the automaton, between 0
+	// and 255.
+	// For example, rule 110 is Turing complete.
+	Rule int
+
+	// Width is the number of cells in a row.
+	Width int
+
+	// Generations is the number of times the automaton is
+	// applied to the input row to get the output row.
+	Generations int
+
+	// Periodic indicates whether the row wraps around.
+	// If false, the cells beyond the ends of the row are
+	// always 0.
+	Periodic bool
+}
+
+// InputSize returns 2, since the first input is for cells
+// and the second is for the delimiter.
+func (c *CellularAutomatonTask) InputSize() int {
+	return 2
+}
+
+// OutputSize returns 1, since each cell is a single bit.
+func (c *CellularAutomatonTask) OutputSize() int {
+	return 1
+}
+
+// NewSamples creates a set of samples.
+func (c *CellularAutomatonTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		row := make([]int, c.Width)
+		for j := range row {
+			row[j] = rand.Intn(2)
+			sample.Inputs = append(sample.Inputs, []float64{float64(row[j]), 0})
+			sample.Outputs = append(sample.Outputs, []float64{0})
+		}
+		sample.Inputs = append(sample.Inputs, []float64{0, 1})
+		sample.Outputs = append(sample.Outputs, []float64{0})
+		for j := 0; j < c.Generations; j++ {
+			row = c.step(row)
+		}
+		for _, cell := range row {
+			sample.Inputs = append(sample.Inputs, []float64{0, 0})
+			sample.Outputs = append(sample.Outputs, []float64{float64(cell)})
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct (rounded) cells
+// after the delimiter.
+func (c *CellularAutomatonTask) Score(m Model, batchSize, batchCount int) float64 {
+	return roundedBinaryTailScore(c, m, batchSize, batchCount, func(s []linalg.Vector) int {
+		for i, x := range s {
+			if x[1] == 1 {
+				return i + 1
+			}
+		}
+		panic("no tail found")
+	})
+}
+
+// step applies the automaton's rule to a row.
+func (c *CellularAutomatonTask) step(row []int) []int {
+	res := make([]int, len(row))
+	for i := range row {
+		pattern := c.cell(row, i-1)<<2 | row[i]<<1 | c.cell(row, i+1)
+		res[i] = (c.Rule >> uint(pattern)) & 1
+	}
+	return res
+}
+
+func (c *CellularAutomatonTask) cell(row []int, i int) int {
+	if c.Periodic {
+		return row[(i+len(row))%len(row)]
+	} else if i < 0 || i >= len(row) {
+		return 0
+	}
+	return row[i]
+}
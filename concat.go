@@ -0,0 +1,120 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// ConcatTask wraps a Task and concatenates several of its
+// samples (episodes) into one long sequence.
+// This tests whether a model can handle several episodes
+// without having its state reset in between.
+type ConcatTask struct {
+	Task Task
+
+	// Count is the number of episodes per sequence.
+	Count int
+
+	// ResetMarker, if true, inserts a timestep between
+	// consecutive episodes to indicate that a new episode
+	// is starting.
+	// The marker is given by an extra input component, and
+	// the model must output zeroes at marker timesteps.
+	ResetMarker bool
+}
+
+// InputSize returns the input size of the wrapped task,
+// plus one if c.ResetMarker is set.
+func (c *ConcatTask) InputSize() int {
+	if c.ResetMarker {
+		return c.Task.InputSize() + 1
+	}
+	return c.Task.InputSize()
+}
+
+// OutputSize returns the output size of the wrapped task.
+func (c *ConcatTask) OutputSize() int {
+	return c.Task.OutputSize()
+}
+
+// NewSamples creates sequences of concatenated episodes.
+func (c *ConcatTask) NewSamples(n int) sgd.SampleSet {
+	episodes := c.Task.NewSamples(n * c.Count)
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		var chain []seqtoseq.Sample
+		for j := 0; j < c.Count; j++ {
+			chain = append(chain, episodes.GetSample(i*c.Count+j).(seqtoseq.Sample))
+		}
+		sample, _ := c.concat(chain)
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score scores the model on the wrapped task.
+// Each episode that the wrapped task scores is placed at a
+// random position among c.Count-1 other episodes from the
+// wrapped task's NewSamples.
+// Only the outputs for the scored episode are counted.
+func (c *ConcatTask) Score(m Model, batchSize, batchCount int) float64 {
+	return c.Task.Score(&concatModel{Model: m, Concat: c}, batchSize, batchCount)
+}
+
+// concat joins episodes into one sequence and returns the
+// index of the first timestep of each episode.
+func (c *ConcatTask) concat(episodes []seqtoseq.Sample) (seqtoseq.Sample, []int) {
+	var res seqtoseq.Sample
+	var starts []int
+	for i, episode := range episodes {
+		if i > 0 && c.ResetMarker {
+			marker := make(linalg.Vector, c.InputSize())
+			marker[len(marker)-1] = 1
+			res.Inputs = append(res.Inputs, marker)
+			res.Outputs = append(res.Outputs, make(linalg.Vector, c.OutputSize()))
+		}
+		starts = append(starts, len(res.Inputs))
+		for _, in := range episode.Inputs {
+			res.Inputs = append(res.Inputs, padVector(in, c.InputSize()))
+		}
+		res.Outputs = append(res.Outputs, episode.Outputs...)
+	}
+	return res, starts
+}
+
+// A concatModel runs a model on episodes surrounded by
+// other episodes, and returns the outputs for the original
+// episodes.
+type concatModel struct {
+	Model
+	Concat *ConcatTask
+}
+
+func (c *concatModel) Run(inputs [][]linalg.Vector) [][]linalg.Vector {
+	fillers := c.Concat.Task.NewSamples(len(inputs) * (c.Concat.Count - 1))
+	var chains [][]linalg.Vector
+	var starts []int
+	for i, seq := range inputs {
+		var episodes []seqtoseq.Sample
+		for j := 0; j < c.Concat.Count-1; j++ {
+			filler := fillers.GetSample(i*(c.Concat.Count-1) + j).(seqtoseq.Sample)
+			episodes = append(episodes, filler)
+		}
+		pos := rand.Intn(c.Concat.Count)
+		episodes = append(episodes, seqtoseq.Sample{})
+		copy(episodes[pos+1:], episodes[pos:])
+		episodes[pos] = seqtoseq.Sample{Inputs: seq}
+		chain, episodeStarts := c.Concat.concat(episodes)
+		chains = append(chains, chain.Inputs)
+		starts = append(starts, episodeStarts[pos])
+	}
+	outputs := c.Model.Run(chains)
+	res := make([][]linalg.Vector, len(inputs))
+	for i, seq := range inputs {
+		res[i] = outputs[i][starts[i] : starts[i]+len(seq)]
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package seqtasks
+
+// ContinualBenchmark measures catastrophic forgetting by
+// training a model on a sequence of tasks, one after
+// another.
+//
+// The tasks are combined as in MixtureTask, so the model
+// sees each task's inputs with a task ID and padding.
+// The model should therefore have the input and output
+// sizes given by the benchmark's InputSize and OutputSize.
+type ContinualBenchmark struct {
+	Tasks []Task
+
+	// MaxEpochs is the maximum number of epochs to train
+	// on each task.
+	MaxEpochs int
+
+	// MaxScore is the score at which training on a task
+	// stops early.
+	MaxScore float64
+
+	// TrainingSize is the number of samples per epoch.
+	TrainingSize int
+
+	// TestingBatch and TestingCount are the batch size and
+	// batch count passed to the tasks' Score methods.
+	TestingBatch int
+	TestingCount int
+}
+
+// InputSize returns the input size that the model must
+// have.
+func (c *ContinualBenchmark) InputSize() int {
+	return c.phaseMixture(0).InputSize()
+}
+
+// OutputSize returns the output size that the model must
+// have.
+func (c *ContinualBenchmark) OutputSize() int {
+	return c.phaseMixture(0).OutputSize()
+}
+
+// Run trains the model on each task in turn and returns a
+// forgetting matrix.
+// Entry (i, j) of the matrix is the model's score on task
+// j after training on task i.
+// Scores are recorded for every task, including those not
+// yet trained on, so the matrix also shows forward
+// transfer.
+//
+// If logFunc is non-nil, it is called after every epoch
+// with the index of the current task, the epoch number,
+// and the score on the current task.
+func (c *ContinualBenchmark) Run(m Model, logFunc func(task, epoch int, score float64)) [][]float64 {
+	var res [][]float64
+	for i := range c.Tasks {
+		mixture := c.phaseMixture(i)
+		subtask := mixture.paddedTasks()[i]
+		for epoch := 0; epoch < c.MaxEpochs; epoch++ {
+			m.Train(mixture.NewSamples(c.TrainingSize))
+			score := subtask.Score(m, c.TestingBatch, c.TestingCount)
+			if logFunc != nil {
+				logFunc(i, epoch, score)
+			}
+			if score >= c.MaxScore {
+				break
+			}
+		}
+		res = append(res, mixture.SubtaskScores(m, c.TestingBatch, c.TestingCount))
+	}
+	return res
+}
+
+// phaseMixture creates a MixtureTask which only samples
+// from the given task.
+func (c *ContinualBenchmark) phaseMixture(taskIdx int) *MixtureTask {
+	weights := make([]float64, len(c.Tasks))
+	weights[taskIdx] = 1
+	return &MixtureTask{Tasks: c.Tasks, Weights: weights}
+}
@@ -0,0 +1,51 @@
+package seqtasks
+
+import "github.com/unixpickle/num-analysis/linalg"
+
+// DecodeTokens converts a sequence of output vectors into a
+// string of tokens, where each token is the index of the
+// largest component of an output vector.
+// Decoding stops at the first end-of-sequence token, which
+// is not included in the result.
+func DecodeTokens(outputs []linalg.Vector, eos int) []int {
+	var res []int
+	for _, vec := range outputs {
+		token := maxIdx(vec)
+		if token == eos {
+			break
+		}
+		res = append(res, token)
+	}
+	return res
+}
+
+// EditDistance computes the Levenshtein distance between
+// two token strings, i.e. the minimum number of insertions,
+// deletions, and substitutions needed to turn one string
+// into the other.
+func EditDistance(a, b []int) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			next := diag
+			if a[i-1] != b[j-1] {
+				next = 1 + minInt(diag, minInt(row[j-1], row[j]))
+			}
+			diag = row[j]
+			row[j] = next
+		}
+	}
+	return row[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
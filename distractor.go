@@ -0,0 +1,78 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+)
+
+// DistractorTask wraps a Task and inserts random distractor
+// timesteps into its sequences.
+//
+// The model must output zeroes at distractor timesteps.
+// The expected outputs at the other timesteps are
+// unchanged, so the model must learn to ignore the
+// distractors.
+type DistractorTask struct {
+	Task Task
+
+	// Prob is the probability of inserting a distractor
+	// before a timestep.
+	// Several distractors may be inserted in a row, each
+	// with probability Prob.
+	// It must be less than 1.
+	Prob float64
+
+	// Symbols lists the input components which may be set
+	// to 1 in a distractor.
+	// Each distractor is a one-hot vector for a random one
+	// of these components.
+	// If this is empty, distractors are all zeroes.
+	//
+	// Components with special meanings, such as delimiters,
+	// should not be listed.
+	Symbols []int
+}
+
+// InputSize returns the input size of the wrapped task.
+func (d *DistractorTask) InputSize() int {
+	return d.Task.InputSize()
+}
+
+// OutputSize returns the output size of the wrapped task.
+func (d *DistractorTask) OutputSize() int {
+	return d.Task.OutputSize()
+}
+
+// NewSamples creates samples with the wrapped task and
+// inserts distractors into them.
+func (d *DistractorTask) NewSamples(n int) sgd.SampleSet {
+	return retimeSamples(d, d.Task.NewSamples(n), d.InputSize(), d.OutputSize())
+}
+
+// Score scores the model on the wrapped task, inserting
+// distractors into every sequence the model sees.
+// Outputs at distractor timesteps are not scored.
+func (d *DistractorTask) Score(m Model, batchSize, batchCount int) float64 {
+	return d.Task.Score(&retimedModel{Model: m, Retimer: d}, batchSize, batchCount)
+}
+
+func (d *DistractorTask) sources(seqLen int) []int {
+	var res []int
+	for i := 0; i < seqLen; i++ {
+		for rand.Float64() < d.Prob {
+			res = append(res, -1)
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+func (d *DistractorTask) insertedInput(inSize int) linalg.Vector {
+	res := make(linalg.Vector, inSize)
+	if len(d.Symbols) > 0 {
+		res[d.Symbols[rand.Intn(len(d.Symbols))]] = 1
+	}
+	return res
+}
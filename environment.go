@@ -0,0 +1,184 @@
+package seqtasks
+
+import (
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// An Environment is a closed-loop task, in which each input
+// to the model may depend on the model's previous outputs.
+//
+// An Environment runs one episode at a time.
+// At every timestep, the model observes an input and
+// produces an output, which the environment uses to choose
+// the next input and a reward.
+type Environment interface {
+	// InputSize is the size of the observation vectors.
+	InputSize() int
+
+	// OutputSize is the size of the model's output vectors.
+	OutputSize() int
+
+	// Reset starts a new episode.
+	Reset()
+
+	// Observe returns the input for the current timestep.
+	Observe() linalg.Vector
+
+	// Target returns the output that a teacher would give
+	// for the current timestep.
+	// It may be used as a supervised training target.
+	Target() linalg.Vector
+
+	// Step advances the environment, given the model's
+	// output for the current timestep.
+	// It returns a reward and a flag indicating whether the
+	// episode is over.
+	// Every episode must end after a finite number of steps.
+	Step(output linalg.Vector) (reward float64, done bool)
+}
+
+// A StreamModel processes a sequence one timestep at a
+// time, so that its inputs may depend on its outputs.
+type StreamModel interface {
+	// Reset starts a new sequence.
+	Reset()
+
+	// Step feeds the next input of the sequence and returns
+	// the corresponding output.
+	Step(input linalg.Vector) linalg.Vector
+}
+
+// NewStreamModel creates a StreamModel which works by
+// re-running m on the entire sequence at every timestep.
+// This takes quadratic time in the sequence length, but it
+// works for any Model.
+func NewStreamModel(m Model) StreamModel {
+	return &prefixStreamModel{model: m}
+}
+
+type prefixStreamModel struct {
+	model   Model
+	history []linalg.Vector
+}
+
+func (p *prefixStreamModel) Reset() {
+	p.history = nil
+}
+
+func (p *prefixStreamModel) Step(input linalg.Vector) linalg.Vector {
+	p.history = append(p.history, input)
+	outs := p.model.Run([][]linalg.Vector{p.history})[0]
+	return outs[len(outs)-1]
+}
+
+// RunEpisode runs one episode of an environment and
+// returns the total reward.
+func RunEpisode(e Environment, m StreamModel) float64 {
+	e.Reset()
+	m.Reset()
+	var total float64
+	for {
+		reward, done := e.Step(m.Step(e.Observe()))
+		total += reward
+		if done {
+			return total
+		}
+	}
+}
+
+// EnvironmentTask turns an Environment into a Task.
+//
+// Training samples are episodes in which the environment
+// is driven by its own targets, as in teacher forcing.
+// Scoring is done in closed loop, using the model's own
+// outputs.
+type EnvironmentTask struct {
+	// NewEnvironment creates an instance of the environment.
+	// Multiple instances are used to run episodes in
+	// parallel.
+	NewEnvironment func() Environment
+}
+
+// InputSize returns the environment's input size.
+func (e *EnvironmentTask) InputSize() int {
+	return e.NewEnvironment().InputSize()
+}
+
+// OutputSize returns the environment's output size.
+func (e *EnvironmentTask) OutputSize() int {
+	return e.NewEnvironment().OutputSize()
+}
+
+// NewSamples creates a set of teacher-driven episodes.
+func (e *EnvironmentTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	env := e.NewEnvironment()
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		env.Reset()
+		for {
+			target := env.Target()
+			sample.Inputs = append(sample.Inputs, env.Observe())
+			sample.Outputs = append(sample.Outputs, target)
+			if _, done := env.Step(target); done {
+				break
+			}
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the average total reward per episode.
+//
+// If the model implements StreamModel, episodes are run one
+// at a time using the model's Step method.
+// Otherwise, batches of episodes are run in parallel, and
+// the model is re-run on the full history of each episode
+// at every timestep.
+func (e *EnvironmentTask) Score(model Model, batchSize, batchCount int) float64 {
+	var total float64
+	if stream, ok := model.(StreamModel); ok {
+		env := e.NewEnvironment()
+		for i := 0; i < batchSize*batchCount; i++ {
+			total += RunEpisode(env, stream)
+		}
+	} else {
+		for i := 0; i < batchCount; i++ {
+			total += e.runBatch(model, batchSize)
+		}
+	}
+	return total / float64(batchSize*batchCount)
+}
+
+// runBatch runs a batch of episodes in parallel and
+// returns the sum of their total rewards.
+func (e *EnvironmentTask) runBatch(model Model, batchSize int) float64 {
+	envs := make([]Environment, batchSize)
+	histories := make([][]linalg.Vector, batchSize)
+	for i := range envs {
+		envs[i] = e.NewEnvironment()
+		envs[i].Reset()
+	}
+	var total float64
+	for len(envs) > 0 {
+		for i, env := range envs {
+			histories[i] = append(histories[i], env.Observe())
+		}
+		outs := model.Run(histories)
+		var nextEnvs []Environment
+		var nextHistories [][]linalg.Vector
+		for i, env := range envs {
+			reward, done := env.Step(outs[i][len(outs[i])-1])
+			total += reward
+			if !done {
+				nextEnvs = append(nextEnvs, env)
+				nextHistories = append(nextHistories, histories[i])
+			}
+		}
+		envs, histories = nextEnvs, nextHistories
+	}
+	return total
+}
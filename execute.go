@@ -0,0 +1,348 @@
+package seqtasks
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// executeVocab is the character vocabulary used by the
+// programs in ExecuteTask.
+const executeVocab = "0123456789abcdefghijklmnopqrstuvwxyz+=<>:() \n"
+
+// executeVars are the variable names which programs in
+// ExecuteTask may assign to.
+const executeVars = "abcd"
+
+// ExecuteTask requires the model to evaluate small,
+// randomly generated programs, as in "Learning to Execute"
+// by Zaremba and Sutskever.
+//
+// Programs consist of assignments, additions, if-statements,
+// and for-loops, written in a Python-like syntax.
+// Every program ends with a print statement.
+// For example:
+//
+//	a=35
+//	for i in range(3):
+//	  if a<60:
+//	    a+=12
+//	print(a+4)
+//
+// The program is fed to the model one character at a time,
+// followed by a delimiter.
+// After the delimiter, the model must output the printed
+// number one digit at a time (most significant digit first),
+// followed by an end symbol.
+type ExecuteTask struct {
+	// MaxDigits is the maximum number of digits in a
+	// numerical constant.
+	MaxDigits int
+
+	// MaxNesting is the maximum depth of nested if-statements
+	// and for-loops.
+	// If this is 0, programs are straight-line code.
+	MaxNesting int
+
+	// MaxStatements is the maximum number of statements in
+	// the body of a program, if-statement, or for-loop.
+	MaxStatements int
+
+	// MaxIterations is the maximum number of times a single
+	// for-loop may run.
+	MaxIterations int
+}
+
+// InputSize returns the number of characters in the
+// program vocabulary, plus one for the delimiter.
+func (e *ExecuteTask) InputSize() int {
+	return len(executeVocab) + 1
+}
+
+// OutputSize returns 11, since there are 10 digits and an
+// end symbol.
+func (e *ExecuteTask) OutputSize() int {
+	return 11
+}
+
+// NewSamples creates a set of samples.
+func (e *ExecuteTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroIn := make(linalg.Vector, e.InputSize())
+	zeroOut := make(linalg.Vector, e.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		code, output := e.randomProgram()
+		for _, ch := range code {
+			inVec := make(linalg.Vector, e.InputSize())
+			inVec[strings.IndexRune(executeVocab, ch)] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			sample.Outputs = append(sample.Outputs, zeroOut)
+		}
+		inDelimiter := make(linalg.Vector, e.InputSize())
+		inDelimiter[len(executeVocab)] = 1
+		sample.Inputs = append(sample.Inputs, inDelimiter)
+		sample.Outputs = append(sample.Outputs, zeroOut)
+		for _, ch := range strconv.Itoa(output) {
+			outVec := make(linalg.Vector, e.OutputSize())
+			outVec[ch-'0'] = 1
+			sample.Inputs = append(sample.Inputs, zeroIn)
+			sample.Outputs = append(sample.Outputs, outVec)
+		}
+		outEnd := make(linalg.Vector, e.OutputSize())
+		outEnd[10] = 1
+		sample.Inputs = append(sample.Inputs, zeroIn)
+		sample.Outputs = append(sample.Outputs, outEnd)
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct (rounded) outputs
+// after the delimiter.
+func (e *ExecuteTask) Score(model Model, batchSize, batchCount int) float64 {
+	return roundedBinaryTailScore(e, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		for i, x := range s {
+			if x[len(executeVocab)] == 1 {
+				return i + 1
+			}
+		}
+		panic("no tail found")
+	})
+}
+
+// randomProgram generates the code for a random program
+// and computes the number it prints.
+func (e *ExecuteTask) randomProgram() (code string, output int) {
+	var defined []byte
+	body := e.randomBlock(0, &defined)
+	result := e.randomExpr(defined)
+
+	var buf bytes.Buffer
+	for _, stmt := range body {
+		stmt.write(&buf, 0)
+	}
+	buf.WriteString("print(")
+	result.write(&buf)
+	buf.WriteString(")")
+
+	vars := map[byte]int{}
+	for _, stmt := range body {
+		stmt.run(vars)
+	}
+	return buf.String(), result.eval(vars)
+}
+
+// randomBlock generates a list of statements at the given
+// nesting depth.
+//
+// The defined argument lists the variables which are
+// guaranteed to be assigned before the block runs.
+// At the top level, it is updated to include any variables
+// that the block assigns.
+func (e *ExecuteTask) randomBlock(depth int, defined *[]byte) []execStatement {
+	var res []execStatement
+	count := rand.Intn(e.MaxStatements) + 1
+	for i := 0; i < count; i++ {
+		kind := 0
+		if depth < e.MaxNesting && len(*defined) > 0 {
+			kind = rand.Intn(3)
+		}
+		switch kind {
+		case 0:
+			res = append(res, e.randomAssign(depth, defined))
+		case 1:
+			res = append(res, &execIf{
+				left:  e.randomTerm(*defined),
+				right: e.randomTerm(*defined),
+				less:  rand.Intn(2) == 0,
+				body:  e.randomBlock(depth+1, defined),
+			})
+		case 2:
+			res = append(res, &execFor{
+				loopVar: byte('i' + depth),
+				count:   rand.Intn(e.MaxIterations) + 1,
+				body:    e.randomBlock(depth+1, defined),
+			})
+		}
+	}
+	return res
+}
+
+func (e *ExecuteTask) randomAssign(depth int, defined *[]byte) *execAssign {
+	if depth > 0 {
+		// Nested assignments must not introduce variables,
+		// since they might never run.
+		// They only use constants, so that loops cannot make
+		// values grow exponentially.
+		res := &execAssign{value: e.randomExpr(nil)}
+		res.name = (*defined)[rand.Intn(len(*defined))]
+		res.add = rand.Intn(2) == 0
+		return res
+	}
+	res := &execAssign{value: e.randomExpr(*defined)}
+	res.name = executeVars[rand.Intn(len(executeVars))]
+	if bytes.IndexByte(*defined, res.name) >= 0 {
+		res.add = rand.Intn(2) == 0
+	} else {
+		*defined = append(*defined, res.name)
+	}
+	return res
+}
+
+func (e *ExecuteTask) randomExpr(defined []byte) execExpr {
+	res := execExpr{e.randomTerm(defined)}
+	if rand.Intn(2) == 0 {
+		res = append(res, e.randomTerm(defined))
+	}
+	return res
+}
+
+func (e *ExecuteTask) randomTerm(defined []byte) execTerm {
+	if len(defined) > 0 && rand.Intn(2) == 0 {
+		return execTerm{name: defined[rand.Intn(len(defined))]}
+	}
+	digitCount := rand.Intn(e.MaxDigits) + 1
+	num := rand.Intn(9) + 1
+	for i := 1; i < digitCount; i++ {
+		num = num*10 + rand.Intn(10)
+	}
+	return execTerm{value: num}
+}
+
+// An execStatement is a statement in an ExecuteTask
+// program.
+type execStatement interface {
+	write(buf *bytes.Buffer, indent int)
+	run(vars map[byte]int)
+}
+
+// An execTerm is either a variable or a constant.
+// If name is 0, the term is a constant.
+type execTerm struct {
+	name  byte
+	value int
+}
+
+func (t execTerm) write(buf *bytes.Buffer) {
+	if t.name != 0 {
+		buf.WriteByte(t.name)
+	} else {
+		buf.WriteString(strconv.Itoa(t.value))
+	}
+}
+
+func (t execTerm) eval(vars map[byte]int) int {
+	if t.name != 0 {
+		return vars[t.name]
+	}
+	return t.value
+}
+
+// An execExpr is a sum of terms.
+type execExpr []execTerm
+
+func (e execExpr) write(buf *bytes.Buffer) {
+	for i, term := range e {
+		if i > 0 {
+			buf.WriteByte('+')
+		}
+		term.write(buf)
+	}
+}
+
+func (e execExpr) eval(vars map[byte]int) int {
+	var sum int
+	for _, term := range e {
+		sum += term.eval(vars)
+	}
+	return sum
+}
+
+type execAssign struct {
+	name  byte
+	add   bool
+	value execExpr
+}
+
+func (a *execAssign) write(buf *bytes.Buffer, indent int) {
+	buf.WriteString(strings.Repeat("  ", indent))
+	buf.WriteByte(a.name)
+	if a.add {
+		buf.WriteByte('+')
+	}
+	buf.WriteByte('=')
+	a.value.write(buf)
+	buf.WriteByte('\n')
+}
+
+func (a *execAssign) run(vars map[byte]int) {
+	if a.add {
+		vars[a.name] += a.value.eval(vars)
+	} else {
+		vars[a.name] = a.value.eval(vars)
+	}
+}
+
+type execIf struct {
+	left  execTerm
+	right execTerm
+	less  bool
+	body  []execStatement
+}
+
+func (i *execIf) write(buf *bytes.Buffer, indent int) {
+	buf.WriteString(strings.Repeat("  ", indent))
+	buf.WriteString("if ")
+	i.left.write(buf)
+	if i.less {
+		buf.WriteByte('<')
+	} else {
+		buf.WriteByte('>')
+	}
+	i.right.write(buf)
+	buf.WriteString(":\n")
+	for _, stmt := range i.body {
+		stmt.write(buf, indent+1)
+	}
+}
+
+func (i *execIf) run(vars map[byte]int) {
+	left, right := i.left.eval(vars), i.right.eval(vars)
+	if (i.less && left < right) || (!i.less && left > right) {
+		for _, stmt := range i.body {
+			stmt.run(vars)
+		}
+	}
+}
+
+type execFor struct {
+	loopVar byte
+	count   int
+	body    []execStatement
+}
+
+func (f *execFor) write(buf *bytes.Buffer, indent int) {
+	buf.WriteString(strings.Repeat("  ", indent))
+	buf.WriteString("for ")
+	buf.WriteByte(f.loopVar)
+	buf.WriteString(" in range(")
+	buf.WriteString(strconv.Itoa(f.count))
+	buf.WriteString("):\n")
+	for _, stmt := range f.body {
+		stmt.write(buf, indent+1)
+	}
+}
+
+func (f *execFor) run(vars map[byte]int) {
+	for i := 0; i < f.count; i++ {
+		for _, stmt := range f.body {
+			stmt.run(vars)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package seqtasks
+
+import (
+	"math/big"
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// ExpressionTask requires the model to evaluate arithmetic
+// expressions made up of numbers, "+", "-", "*", and
+// parentheses.
+//
+// The expression is fed to the model one token at a time,
+// followed by a request marker.
+// After the request marker, the model must output the
+// result, starting with a minus sign if the result is
+// negative.
+// As in AdditionTask, the digits of every number (both in
+// the input and the output) are given from least to most
+// significant.
+// The result is terminated by an end symbol.
+type ExpressionTask struct {
+	// Base is the base of the numbers in the expression.
+	Base int
+
+	// MaxDigits is the maximum number of digits in an
+	// operand.
+	MaxDigits int
+
+	// MaxDepth is the maximum number of nested operations.
+	// If this is 0, expressions are single numbers.
+	MaxDepth int
+
+	// NestProb is the probability that an operand will be
+	// a parenthesized sub-expression rather than a number,
+	// provided that MaxDepth has not been reached.
+	NestProb float64
+}
+
+// InputSize returns the number of input symbols, which
+// varies with the base.
+// The symbols are the digits, the three operators, the
+// two parentheses, and the request marker.
+func (e *ExpressionTask) InputSize() int {
+	return e.Base + 6
+}
+
+// OutputSize returns the number of output symbols, which
+// varies with the base.
+// The symbols are the digits, the minus sign, and the end
+// symbol.
+func (e *ExpressionTask) OutputSize() int {
+	return e.Base + 2
+}
+
+// NewSamples creates a set of samples.
+func (e *ExpressionTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroIn := make(linalg.Vector, e.InputSize())
+	zeroOut := make(linalg.Vector, e.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		var tokens []int
+		result := e.randomExpression(e.MaxDepth, &tokens)
+		tokens = append(tokens, e.Base+5)
+		for _, token := range tokens {
+			inVec := make(linalg.Vector, e.InputSize())
+			inVec[token] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			sample.Outputs = append(sample.Outputs, zeroOut)
+		}
+
+		var outSymbols []int
+		if result.Sign() < 0 {
+			outSymbols = append(outSymbols, e.Base)
+		}
+		outSymbols = append(outSymbols, e.bigDigits(result)...)
+		outSymbols = append(outSymbols, e.Base+1)
+		for _, symbol := range outSymbols {
+			outVec := make(linalg.Vector, e.OutputSize())
+			outVec[symbol] = 1
+			sample.Inputs = append(sample.Inputs, zeroIn)
+			sample.Outputs = append(sample.Outputs, outVec)
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct (rounded) outputs
+// after the request marker.
+func (e *ExpressionTask) Score(model Model, batchSize, batchCount int) float64 {
+	return roundedBinaryTailScore(e, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		for i, x := range s {
+			if x[e.Base+5] == 1 {
+				return i + 1
+			}
+		}
+		panic("no tail found")
+	})
+}
+
+// randomExpression appends the tokens of a random
+// expression to tokens and returns the expression's value.
+func (e *ExpressionTask) randomExpression(depth int, tokens *[]int) *big.Int {
+	if depth == 0 {
+		return e.randomNumber(tokens)
+	}
+	left := e.randomOperand(depth-1, tokens)
+	op := rand.Intn(3)
+	*tokens = append(*tokens, e.Base+op)
+	right := e.randomOperand(depth-1, tokens)
+	switch op {
+	case 0:
+		return left.Add(left, right)
+	case 1:
+		return left.Sub(left, right)
+	default:
+		return left.Mul(left, right)
+	}
+}
+
+func (e *ExpressionTask) randomOperand(depth int, tokens *[]int) *big.Int {
+	if depth == 0 || rand.Float64() >= e.NestProb {
+		return e.randomNumber(tokens)
+	}
+	*tokens = append(*tokens, e.Base+3)
+	res := e.randomExpression(depth, tokens)
+	*tokens = append(*tokens, e.Base+4)
+	return res
+}
+
+func (e *ExpressionTask) randomNumber(tokens *[]int) *big.Int {
+	digitCount := rand.Intn(e.MaxDigits) + 1
+	res := new(big.Int)
+	place := big.NewInt(1)
+	base := big.NewInt(int64(e.Base))
+	for i := 0; i < digitCount; i++ {
+		digit := rand.Intn(e.Base)
+		*tokens = append(*tokens, digit)
+		res.Add(res, new(big.Int).Mul(place, big.NewInt(int64(digit))))
+		place.Mul(place, base)
+	}
+	return res
+}
+
+// bigDigits returns the digits of |x|, starting with the
+// least significant digit.
+func (e *ExpressionTask) bigDigits(x *big.Int) []int {
+	num := new(big.Int).Abs(x)
+	base := big.NewInt(int64(e.Base))
+	digit := new(big.Int)
+	var res []int
+	for {
+		num.DivMod(num, base, digit)
+		res = append(res, int(digit.Int64()))
+		if num.Sign() == 0 {
+			return res
+		}
+	}
+}
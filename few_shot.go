@@ -0,0 +1,136 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/mnist"
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// FewShotTask is a meta-learning task built on MNIST-format
+// data sets.
+//
+// Each sequence is an episode in which the model is shown
+// images from a few randomly chosen classes, one whole image
+// per timestep.
+// The classes are assigned random labels at the start of
+// each episode, and the model must predict the label of
+// each image.
+// Along with each image, the model is given the correct
+// label for the previous timestep, so it can learn the
+// labels as the episode goes on.
+//
+// The samples are grouped by class the first time they are
+// used, so the data sets should not be modified afterwards.
+type FewShotTask struct {
+	Training mnist.DataSet
+	Testing  mnist.DataSet
+
+	// ClassCount is the number of classes in each episode.
+	ClassCount int
+
+	// EpisodeLen is the number of images in each episode.
+	EpisodeLen int
+
+	trainingClasses [][]mnist.Sample
+	testingClasses  [][]mnist.Sample
+}
+
+// InputSize returns the number of pixels per image plus
+// the number of classes per episode, since each input
+// contains an image and the previous label.
+func (f *FewShotTask) InputSize() int {
+	return f.Training.Width*f.Training.Height + f.ClassCount
+}
+
+// OutputSize returns the number of classes per episode.
+func (f *FewShotTask) OutputSize() int {
+	return f.ClassCount
+}
+
+// NewSamples creates a set of episodes from the training
+// data.
+func (f *FewShotTask) NewSamples(n int) sgd.SampleSet {
+	if f.trainingClasses == nil {
+		f.trainingClasses = samplesByLabel(f.Training)
+	}
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		res = append(res, f.episode(f.trainingClasses))
+	}
+	return res
+}
+
+// Score computes the fraction of correctly labeled images
+// in episodes from the testing data, where the model's
+// largest output is taken to be its prediction.
+func (f *FewShotTask) Score(model Model, batchSize, batchCount int) float64 {
+	if f.testingClasses == nil {
+		f.testingClasses = samplesByLabel(f.Testing)
+	}
+	var correct, total int
+	for i := 0; i < batchCount; i++ {
+		var inputs [][]linalg.Vector
+		var expected [][]linalg.Vector
+		for j := 0; j < batchSize; j++ {
+			sample := f.episode(f.testingClasses)
+			inputs = append(inputs, sample.Inputs)
+			expected = append(expected, sample.Outputs)
+		}
+		actual := model.Run(inputs)
+		for lane, expSeq := range expected {
+			for t, expVec := range expSeq {
+				if maxIdx(actual[lane][t]) == maxIdx(expVec) {
+					correct++
+				}
+				total++
+			}
+		}
+	}
+	return float64(correct) / float64(total)
+}
+
+func (f *FewShotTask) episode(classes [][]mnist.Sample) seqtoseq.Sample {
+	perm := rand.Perm(len(classes))[:f.ClassCount]
+
+	var res seqtoseq.Sample
+	lastLabel := -1
+	for i := 0; i < f.EpisodeLen; i++ {
+		label := rand.Intn(f.ClassCount)
+		classSamples := classes[perm[label]]
+		image := classSamples[rand.Intn(len(classSamples))]
+		inVec := make(linalg.Vector, f.InputSize())
+		copy(inVec, image.Intensities)
+		if lastLabel >= 0 {
+			inVec[len(image.Intensities)+lastLabel] = 1
+		}
+		outVec := make(linalg.Vector, f.ClassCount)
+		outVec[label] = 1
+		res.Inputs = append(res.Inputs, inVec)
+		res.Outputs = append(res.Outputs, outVec)
+		lastLabel = label
+	}
+	return res
+}
+
+// samplesByLabel groups the samples in a data set by
+// their labels.
+// Labels with no samples are omitted.
+func samplesByLabel(d mnist.DataSet) [][]mnist.Sample {
+	var byLabel [][]mnist.Sample
+	for _, sample := range d.Samples {
+		for len(byLabel) <= sample.Label {
+			byLabel = append(byLabel, nil)
+		}
+		byLabel[sample.Label] = append(byLabel[sample.Label], sample)
+	}
+	var res [][]mnist.Sample
+	for _, samples := range byLabel {
+		if len(samples) > 0 {
+			res = append(res, samples)
+		}
+	}
+	return res
+}
@@ -0,0 +1,18 @@
+package seqtasks
+
+import (
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// forecastSample creates a sample which requires the
+// model to predict each point in a time series the given
+// number of timesteps in advance.
+// The series must be horizon points longer than the
+// resulting sample.
+func forecastSample(series []linalg.Vector, horizon int) seqtoseq.Sample {
+	return seqtoseq.Sample{
+		Inputs:  series[:len(series)-horizon],
+		Outputs: series[horizon:],
+	}
+}
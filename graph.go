@@ -0,0 +1,255 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// A GraphQuery is a type of question asked about the graph
+// in a GraphTask.
+type GraphQuery int
+
+const (
+	// ReachabilityQuery asks whether there is a path from
+	// the source to the target.
+	// The answer is a single bit.
+	ReachabilityQuery GraphQuery = iota
+
+	// DistanceQuery asks for the length of the shortest
+	// path from the source to the target.
+	// The answer is a one-hot vector, where the last
+	// component indicates that there is no path.
+	DistanceQuery
+
+	// PathQuery asks for the nodes along the shortest path
+	// from the source to the target, one node per timestep,
+	// followed by an end symbol.
+	// Queries are only made for pairs of nodes with exactly
+	// one shortest path.
+	PathQuery
+)
+
+// GraphTask feeds the model the edges of a random graph,
+// followed by a query about a pair of nodes.
+//
+// Each input gives two nodes as one-hot vectors, followed
+// by a query flag.
+// For edges, the nodes are the endpoints and the query flag
+// is 0.
+// For the query, the nodes are the source and target, and
+// the query flag is 1.
+type GraphTask struct {
+	// NodeCount is the number of nodes in the graph.
+	NodeCount int
+
+	// MinEdges is the minimum number of edges in the graph.
+	MinEdges int
+
+	// MaxEdges is the maximum number of edges in the graph.
+	MaxEdges int
+
+	// Directed indicates whether edges are one-way.
+	Directed bool
+
+	// Query is the type of query to ask.
+	Query GraphQuery
+}
+
+// InputSize returns twice the number of nodes plus one,
+// since each input contains two nodes and a query flag.
+func (g *GraphTask) InputSize() int {
+	return 2*g.NodeCount + 1
+}
+
+// OutputSize returns the number of output symbols, which
+// depends on g.Query.
+func (g *GraphTask) OutputSize() int {
+	switch g.Query {
+	case ReachabilityQuery:
+		return 1
+	case DistanceQuery, PathQuery:
+		return g.NodeCount + 1
+	}
+	panic("unknown query type")
+}
+
+// NewSamples creates a set of samples.
+func (g *GraphTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroIn := make(linalg.Vector, g.InputSize())
+	zeroOut := make(linalg.Vector, g.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		edges, source, target, path := g.randomQuery()
+		for _, edge := range edges {
+			inVec := make(linalg.Vector, g.InputSize())
+			inVec[edge[0]] = 1
+			inVec[g.NodeCount+edge[1]] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			sample.Outputs = append(sample.Outputs, zeroOut)
+		}
+		query := make(linalg.Vector, g.InputSize())
+		query[source] = 1
+		query[g.NodeCount+target] = 1
+		query[2*g.NodeCount] = 1
+		sample.Inputs = append(sample.Inputs, query)
+
+		switch g.Query {
+		case ReachabilityQuery:
+			if path != nil {
+				sample.Outputs = append(sample.Outputs, []float64{1})
+			} else {
+				sample.Outputs = append(sample.Outputs, []float64{0})
+			}
+		case DistanceQuery:
+			outVec := make(linalg.Vector, g.OutputSize())
+			if path != nil {
+				outVec[len(path)-1] = 1
+			} else {
+				outVec[g.NodeCount] = 1
+			}
+			sample.Outputs = append(sample.Outputs, outVec)
+		case PathQuery:
+			sample.Outputs = append(sample.Outputs, zeroOut)
+			for _, node := range path {
+				outVec := make(linalg.Vector, g.OutputSize())
+				outVec[node] = 1
+				sample.Inputs = append(sample.Inputs, zeroIn)
+				sample.Outputs = append(sample.Outputs, outVec)
+			}
+			outEnd := make(linalg.Vector, g.OutputSize())
+			outEnd[g.NodeCount] = 1
+			sample.Inputs = append(sample.Inputs, zeroIn)
+			sample.Outputs = append(sample.Outputs, outEnd)
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct answers.
+// For ReachabilityQuery, outputs are rounded to 0 or 1.
+// For other queries, the model's largest output at each
+// timestep of the answer is taken to be its answer.
+func (g *GraphTask) Score(model Model, batchSize, batchCount int) float64 {
+	tailFunc := func(s []linalg.Vector) int {
+		for i, x := range s {
+			if x[2*g.NodeCount] == 1 {
+				if g.Query == PathQuery {
+					return i + 1
+				}
+				return i
+			}
+		}
+		panic("no tail found")
+	}
+	if g.Query == ReachabilityQuery {
+		return roundedBinaryTailScore(g, model, batchSize, batchCount, tailFunc)
+	}
+	return argmaxTailScore(g, model, batchSize, batchCount, tailFunc)
+}
+
+// randomQuery generates a random graph and a pair of
+// distinct nodes to query.
+// If the target is reachable, it also returns the nodes
+// along a shortest path, including the source and target.
+func (g *GraphTask) randomQuery() (edges [][2]int, source, target int, path []int) {
+	for {
+		edges = g.randomEdges()
+		neighbors := make([][]int, g.NodeCount)
+		for _, edge := range edges {
+			neighbors[edge[0]] = append(neighbors[edge[0]], edge[1])
+			if !g.Directed {
+				neighbors[edge[1]] = append(neighbors[edge[1]], edge[0])
+			}
+		}
+		if g.Query != PathQuery {
+			source = rand.Intn(g.NodeCount)
+			target = rand.Intn(g.NodeCount - 1)
+			if target >= source {
+				target++
+			}
+			path, _ = shortestPath(neighbors, source, target)
+			return
+		}
+		for _, pair := range rand.Perm(g.NodeCount * g.NodeCount) {
+			source, target = pair/g.NodeCount, pair%g.NodeCount
+			if source == target {
+				continue
+			}
+			var unique bool
+			path, unique = shortestPath(neighbors, source, target)
+			if path != nil && unique {
+				return
+			}
+		}
+	}
+}
+
+func (g *GraphTask) randomEdges() [][2]int {
+	var candidates [][2]int
+	for i := 0; i < g.NodeCount; i++ {
+		for j := 0; j < g.NodeCount; j++ {
+			if i != j && (g.Directed || i < j) {
+				candidates = append(candidates, [2]int{i, j})
+			}
+		}
+	}
+	edgeCount := rand.Intn(g.MaxEdges-g.MinEdges+1) + g.MinEdges
+	if edgeCount > len(candidates) {
+		edgeCount = len(candidates)
+	}
+	var res [][2]int
+	for _, idx := range rand.Perm(len(candidates))[:edgeCount] {
+		edge := candidates[idx]
+		if !g.Directed && rand.Intn(2) == 0 {
+			edge[0], edge[1] = edge[1], edge[0]
+		}
+		res = append(res, edge)
+	}
+	return res
+}
+
+// shortestPath runs a breadth-first search to find a
+// shortest path between two nodes.
+// It returns nil if there is no path.
+// It also reports whether the shortest path is unique.
+func shortestPath(neighbors [][]int, source, target int) (path []int, unique bool) {
+	dists := make([]int, len(neighbors))
+	counts := make([]int, len(neighbors))
+	parents := make([]int, len(neighbors))
+	for i := range dists {
+		dists[i] = -1
+	}
+	dists[source] = 0
+	counts[source] = 1
+	queue := []int{source}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range neighbors[node] {
+			if dists[next] < 0 {
+				dists[next] = dists[node] + 1
+				parents[next] = node
+				queue = append(queue, next)
+			}
+			if dists[next] == dists[node]+1 {
+				// Cap the count to avoid overflow.
+				counts[next] += counts[node]
+				if counts[next] > 2 {
+					counts[next] = 2
+				}
+			}
+		}
+	}
+	if dists[target] < 0 {
+		return nil, false
+	}
+	for node := target; node != source; node = parents[node] {
+		path = append([]int{node}, path...)
+	}
+	return append([]int{source}, path...), counts[target] == 1
+}
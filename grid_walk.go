@@ -0,0 +1,120 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// GridWalkTask requires the model to keep track of its
+// position on a 2D grid as it is fed a sequence of moves.
+//
+// The walk starts in the center of the grid, at
+// (Width/2, Height/2).
+// Each move is up, down, left, or right.
+// Moves into the edge of the grid do nothing.
+//
+// The model reports its position as two one-hot vectors,
+// one for the x coordinate followed by one for the y
+// coordinate.
+type GridWalkTask struct {
+	Width  int
+	Height int
+
+	// MinLen is the minimum number of moves.
+	MinLen int
+
+	// MaxLen is the maximum number of moves.
+	MaxLen int
+
+	// Continuous determines when the model must report its
+	// position.
+	//
+	// If false, the moves are followed by a query marker,
+	// and the model must only report its position at the
+	// query marker.
+	//
+	// If true, the model must report its position after
+	// every move.
+	Continuous bool
+}
+
+// InputSize returns 5, since there are four moves and a
+// query marker.
+func (g *GridWalkTask) InputSize() int {
+	return 5
+}
+
+// OutputSize returns the width plus the height, since the
+// model must output each coordinate as a one-hot vector.
+func (g *GridWalkTask) OutputSize() int {
+	return g.Width + g.Height
+}
+
+// NewSamples creates a set of samples.
+func (g *GridWalkTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroOut := make(linalg.Vector, g.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		x, y := g.Width/2, g.Height/2
+		walkLen := rand.Intn(g.MaxLen-g.MinLen+1) + g.MinLen
+		for j := 0; j < walkLen; j++ {
+			move := rand.Intn(4)
+			switch move {
+			case 0:
+				y = clampInt(y-1, 0, g.Height-1)
+			case 1:
+				y = clampInt(y+1, 0, g.Height-1)
+			case 2:
+				x = clampInt(x-1, 0, g.Width-1)
+			case 3:
+				x = clampInt(x+1, 0, g.Width-1)
+			}
+			inVec := make(linalg.Vector, 5)
+			inVec[move] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			if g.Continuous {
+				sample.Outputs = append(sample.Outputs, g.positionVec(x, y))
+			} else {
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			}
+		}
+		if !g.Continuous {
+			sample.Inputs = append(sample.Inputs, []float64{0, 0, 0, 0, 1})
+			sample.Outputs = append(sample.Outputs, g.positionVec(x, y))
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct (rounded) outputs.
+// If g.Continuous is false, only the query marker's output
+// is counted.
+func (g *GridWalkTask) Score(model Model, batchSize, batchCount int) float64 {
+	return roundedBinaryTailScore(g, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		if g.Continuous {
+			return 0
+		}
+		return len(s) - 1
+	})
+}
+
+func (g *GridWalkTask) positionVec(x, y int) linalg.Vector {
+	res := make(linalg.Vector, g.OutputSize())
+	res[x] = 1
+	res[g.Width+y] = 1
+	return res
+}
+
+func clampInt(x, min, max int) int {
+	if x < min {
+		return min
+	} else if x > max {
+		return max
+	}
+	return x
+}
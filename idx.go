@@ -0,0 +1,119 @@
+package seqtasks
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/unixpickle/mnist"
+)
+
+// idxTypeUnsignedByte is the IDX type code for unsigned
+// bytes, the only data type used by MNIST-format files.
+const idxTypeUnsignedByte = 0x08
+
+// LoadIDXDataSet loads an MNIST-format data set from an
+// IDX image file and an IDX label file.
+// This can be used to load Fashion-MNIST, KMNIST, EMNIST,
+// and the like.
+//
+// Files ending in ".gz" are decompressed automatically.
+// Pixel intensities are scaled to be between 0 and 1.
+// Such data sets needn't have 10 classes; MNISTTask
+// detects the number of classes from the labels unless
+// its ClassCount field is set.
+func LoadIDXDataSet(imagePath, labelPath string) (mnist.DataSet, error) {
+	imageDims, images, err := readIDXFile(imagePath)
+	if err != nil {
+		return mnist.DataSet{}, err
+	}
+	labelDims, labels, err := readIDXFile(labelPath)
+	if err != nil {
+		return mnist.DataSet{}, err
+	}
+	if len(imageDims) != 3 {
+		return mnist.DataSet{}, fmt.Errorf("load %s: expected 3 dimensions but got %d",
+			imagePath, len(imageDims))
+	}
+	if len(labelDims) != 1 {
+		return mnist.DataSet{}, fmt.Errorf("load %s: expected 1 dimension but got %d",
+			labelPath, len(labelDims))
+	}
+	if imageDims[0] != labelDims[0] {
+		return mnist.DataSet{}, fmt.Errorf("load %s: %d images but %d labels",
+			imagePath, imageDims[0], labelDims[0])
+	}
+
+	res := mnist.DataSet{
+		Width:  imageDims[2],
+		Height: imageDims[1],
+	}
+	pixelCount := res.Width * res.Height
+	for i, label := range labels {
+		sample := mnist.Sample{
+			Intensities: make([]float64, pixelCount),
+			Label:       int(label),
+		}
+		for j, pixel := range images[i*pixelCount : (i+1)*pixelCount] {
+			sample.Intensities[j] = float64(pixel) / 0xff
+		}
+		res.Samples = append(res.Samples, sample)
+	}
+	return res, nil
+}
+
+// readIDXFile reads an unsigned byte IDX file, returning
+// its dimensions and its raw data.
+func readIDXFile(path string) (dims []int, data []byte, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	var r io.Reader = bufio.NewReader(f)
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load %s: %s", path, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+	dims, data, err = readIDX(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load %s: %s", path, err)
+	}
+	return
+}
+
+func readIDX(r io.Reader) (dims []int, data []byte, err error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, nil, err
+	}
+	if magic[0] != 0 || magic[1] != 0 {
+		return nil, nil, errors.New("invalid IDX magic number")
+	}
+	if magic[2] != idxTypeUnsignedByte {
+		return nil, nil, fmt.Errorf("unsupported IDX data type: 0x%02x", magic[2])
+	}
+	size := 1
+	for i := 0; i < int(magic[3]); i++ {
+		var dim uint32
+		if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+			return nil, nil, err
+		}
+		dims = append(dims, int(dim))
+		size *= int(dim)
+	}
+	data = make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, nil, err
+	}
+	return dims, data, nil
+}
@@ -0,0 +1,288 @@
+package seqtasks
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/unixpickle/mnist"
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// An ImageSet is a set of labeled images which all have
+// the same dimensions.
+type ImageSet struct {
+	Width    int
+	Height   int
+	Channels int
+
+	// ClassNames stores the name of each class, indexed by
+	// label.
+	ClassNames []string
+
+	Samples []ImageSample
+}
+
+// An ImageSample is one labeled image in an ImageSet.
+type ImageSample struct {
+	// Pixels stores the channel values for each pixel in
+	// raster order.
+	// Channel values are between 0 and 1.
+	Pixels []linalg.Vector
+
+	Label int
+}
+
+// LoadImageSet loads a directory of PNG images.
+// The directory must contain one subdirectory per class,
+// and each subdirectory contains the images for its class.
+// Classes are labeled in alphabetical order.
+//
+// If grayscale is true, images are converted to grayscale
+// and have one channel.
+// Otherwise, they have three channels (red, green, blue).
+func LoadImageSet(dir string, grayscale bool) (*ImageSet, error) {
+	listing, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	res := &ImageSet{Channels: 3}
+	if grayscale {
+		res.Channels = 1
+	}
+	for _, classInfo := range listing {
+		if !classInfo.IsDir() {
+			continue
+		}
+		label := len(res.ClassNames)
+		res.ClassNames = append(res.ClassNames, classInfo.Name())
+		classDir := filepath.Join(dir, classInfo.Name())
+		images, err := ioutil.ReadDir(classDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, imageInfo := range images {
+			if strings.ToLower(filepath.Ext(imageInfo.Name())) != ".png" {
+				continue
+			}
+			imagePath := filepath.Join(classDir, imageInfo.Name())
+			img, err := readPNG(imagePath)
+			if err != nil {
+				return nil, err
+			}
+			bounds := img.Bounds()
+			if len(res.Samples) == 0 {
+				res.Width = bounds.Dx()
+				res.Height = bounds.Dy()
+			} else if bounds.Dx() != res.Width || bounds.Dy() != res.Height {
+				return nil, fmt.Errorf("load %s: expected %dx%d image but got %dx%d",
+					imagePath, res.Width, res.Height, bounds.Dx(), bounds.Dy())
+			}
+			sample := ImageSample{Label: label}
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					sample.Pixels = append(sample.Pixels, colorChannels(img.At(x, y), grayscale))
+				}
+			}
+			res.Samples = append(res.Samples, sample)
+		}
+	}
+	return res, nil
+}
+
+// ImageSetFromMNIST converts an MNIST data set into an
+// ImageSet with one channel.
+func ImageSetFromMNIST(d mnist.DataSet) *ImageSet {
+	res := &ImageSet{
+		Width:    d.Width,
+		Height:   d.Height,
+		Channels: 1,
+	}
+	for _, sample := range d.Samples {
+		for len(res.ClassNames) <= sample.Label {
+			res.ClassNames = append(res.ClassNames, strconv.Itoa(len(res.ClassNames)))
+		}
+		imageSample := ImageSample{Label: sample.Label}
+		for _, x := range sample.Intensities {
+			imageSample.Pixels = append(imageSample.Pixels, linalg.Vector{x})
+		}
+		res.Samples = append(res.Samples, imageSample)
+	}
+	return res
+}
+
+// ImageSequenceTask requires the model to classify images,
+// given a string of pixels comprising an image.
+// It is like MNISTTask, but it works for any ImageSet.
+//
+// The training and testing sets must have the same classes
+// and image dimensions.
+// NewImageSequenceTask checks this.
+type ImageSequenceTask struct {
+	Training *ImageSet
+	Testing  *ImageSet
+
+	// RowOrder determines how images are fed to the model.
+	// If true, the model is fed one row of pixels per
+	// timestep.
+	// Otherwise, it is fed one pixel per timestep, in raster
+	// order.
+	RowOrder bool
+}
+
+// NewImageSequenceTask creates an ImageSequenceTask after
+// checking that the training and testing sets are
+// compatible and non-empty.
+func NewImageSequenceTask(training, testing *ImageSet,
+	rowOrder bool) (*ImageSequenceTask, error) {
+	if len(training.Samples) == 0 || len(testing.Samples) == 0 {
+		return nil, errors.New("new image sequence task: empty image set")
+	}
+	if training.Width != testing.Width || training.Height != testing.Height ||
+		training.Channels != testing.Channels {
+		return nil, fmt.Errorf("new image sequence task: training images are %dx%dx%d "+
+			"but testing images are %dx%dx%d", training.Width, training.Height,
+			training.Channels, testing.Width, testing.Height, testing.Channels)
+	}
+	if len(training.ClassNames) != len(testing.ClassNames) {
+		return nil, fmt.Errorf("new image sequence task: %d training classes but %d "+
+			"testing classes", len(training.ClassNames), len(testing.ClassNames))
+	}
+	for j, name := range training.ClassNames {
+		if testing.ClassNames[j] != name {
+			return nil, fmt.Errorf("new image sequence task: class %d is %q for "+
+				"training but %q for testing", j, name, testing.ClassNames[j])
+		}
+	}
+	return &ImageSequenceTask{
+		Training: training,
+		Testing:  testing,
+		RowOrder: rowOrder,
+	}, nil
+}
+
+// LoadImageSequenceTask loads the training and testing
+// sets from directories, as with LoadImageSet, and creates
+// an ImageSequenceTask with NewImageSequenceTask.
+func LoadImageSequenceTask(trainDir, testDir string, grayscale,
+	rowOrder bool) (*ImageSequenceTask, error) {
+	training, err := LoadImageSet(trainDir, grayscale)
+	if err != nil {
+		return nil, err
+	}
+	testing, err := LoadImageSet(testDir, grayscale)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageSequenceTask(training, testing, rowOrder)
+}
+
+// InputSize returns one more than the number of channel
+// values per timestep, since there is an extra
+// "end-of-image" input.
+func (i *ImageSequenceTask) InputSize() int {
+	if i.RowOrder {
+		return i.Training.Channels*i.Training.Width + 1
+	}
+	return i.Training.Channels + 1
+}
+
+// OutputSize returns the number of classes, since the
+// model needs to be able to choose between them.
+func (i *ImageSequenceTask) OutputSize() int {
+	return len(i.Training.ClassNames)
+}
+
+// NewSamples creates a list of training sample sequences.
+func (i *ImageSequenceTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for j := 0; j < n; j++ {
+		var resSample seqtoseq.Sample
+		sample := i.Training.Samples[rand.Intn(len(i.Training.Samples))]
+		resSample.Inputs = i.inputSequence(sample)
+		for k := 1; k < len(resSample.Inputs); k++ {
+			resSample.Outputs = append(resSample.Outputs, make(linalg.Vector, i.OutputSize()))
+		}
+		outVec := make(linalg.Vector, i.OutputSize())
+		outVec[sample.Label] = 1
+		resSample.Outputs = append(resSample.Outputs, outVec)
+		res = append(res, resSample)
+	}
+	return res
+}
+
+// Score computes the fraction of correctly classified
+// images, as measured by the testing data set.
+func (i *ImageSequenceTask) Score(model Model, batchSize, batchCount int) float64 {
+	var correct int
+	for j := 0; j < batchCount; j++ {
+		var labels []int
+		var sequences [][]linalg.Vector
+		for k := 0; k < batchSize; k++ {
+			sample := i.Testing.Samples[rand.Intn(len(i.Testing.Samples))]
+			labels = append(labels, sample.Label)
+			sequences = append(sequences, i.inputSequence(sample))
+		}
+		outs := model.Run(sequences)
+		for k, label := range labels {
+			lastOut := outs[k][len(outs[k])-1]
+			if maxIdx(lastOut) == label {
+				correct++
+			}
+		}
+	}
+	return float64(correct) / float64(batchSize*batchCount)
+}
+
+// inputSequence creates the input sequence for an image,
+// including the trailing "end-of-image" timestep.
+func (i *ImageSequenceTask) inputSequence(sample ImageSample) []linalg.Vector {
+	pixelsPerStep := 1
+	if i.RowOrder {
+		pixelsPerStep = i.Training.Width
+	}
+	var res []linalg.Vector
+	for j, pixel := range sample.Pixels {
+		if j%pixelsPerStep == 0 {
+			res = append(res, make(linalg.Vector, 0, i.InputSize()))
+		}
+		res[len(res)-1] = append(res[len(res)-1], pixel...)
+	}
+	for j, vec := range res {
+		res[j] = append(vec, 0)
+	}
+	end := make(linalg.Vector, i.InputSize())
+	end[len(end)-1] = 1
+	return append(res, end)
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %s", path, err)
+	}
+	return img, nil
+}
+
+func colorChannels(c color.Color, grayscale bool) linalg.Vector {
+	if grayscale {
+		return linalg.Vector{float64(color.Gray16Model.Convert(c).(color.Gray16).Y) / 0xffff}
+	}
+	r, g, b, _ := c.RGBA()
+	return linalg.Vector{float64(r) / 0xffff, float64(g) / 0xffff, float64(b) / 0xffff}
+}
@@ -0,0 +1,117 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+)
+
+const (
+	lorenzSigma    = 10
+	lorenzRho      = 28
+	lorenzBeta     = 8.0 / 3
+	lorenzStep     = 0.01
+	lorenzSubsteps = 5
+	lorenzWashout  = 200
+)
+
+// LorenzTask requires the model to forecast the Lorenz
+// system, a chaotic three-dimensional ODE.
+//
+// At each timestep, the model is given the current state
+// of the system and must predict the state Horizon
+// timesteps later.
+// Timesteps are 0.05 time units apart, and the state
+// variables are scaled to lie roughly between 0 and 1.
+type LorenzTask struct {
+	// SeqLen is the length of test sequences.
+	SeqLen int
+
+	// Horizon is the number of timesteps ahead which the
+	// model must predict.
+	Horizon int
+}
+
+// InputSize returns 3, since the state of the system has
+// three variables.
+func (l *LorenzTask) InputSize() int {
+	return 3
+}
+
+// OutputSize returns 3, since the state of the system has
+// three variables.
+func (l *LorenzTask) OutputSize() int {
+	return 3
+}
+
+// NewSamples creates a set of samples, each starting at a
+// random point on the attractor.
+func (l *LorenzTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		res = append(res, forecastSample(l.series(), l.Horizon))
+	}
+	return res
+}
+
+// Score returns 1 minus the normalized root-mean-square
+// error of the model's predictions.
+// A perfect model scores 1, and a model which always
+// predicts the mean scores 0.
+func (l *LorenzTask) Score(model Model, batchSize, batchCount int) float64 {
+	return 1 - nrmseScore(l, model, batchSize, batchCount)
+}
+
+// series integrates the system from a random initial
+// state and returns l.SeqLen+l.Horizon points.
+func (l *LorenzTask) series() []linalg.Vector {
+	state := linalg.Vector{
+		rand.Float64()*20 - 10,
+		rand.Float64()*20 - 10,
+		rand.Float64()*30 + 10,
+	}
+	var res []linalg.Vector
+	totalSteps := (lorenzWashout + l.SeqLen + l.Horizon) * lorenzSubsteps
+	for step := 0; step < totalSteps; step++ {
+		if step%lorenzSubsteps == 0 && step/lorenzSubsteps >= lorenzWashout {
+			res = append(res, []float64{
+				(state[0] + 25) / 50,
+				(state[1] + 25) / 50,
+				state[2] / 50,
+			})
+		}
+		state = lorenzRK4(state)
+	}
+	return res
+}
+
+// lorenzRK4 advances the state of the system by one step
+// of the fourth-order Runge-Kutta method.
+func lorenzRK4(s linalg.Vector) linalg.Vector {
+	k1 := lorenzDerivative(s)
+	k2 := lorenzDerivative(lorenzOffset(s, k1, lorenzStep/2))
+	k3 := lorenzDerivative(lorenzOffset(s, k2, lorenzStep/2))
+	k4 := lorenzDerivative(lorenzOffset(s, k3, lorenzStep))
+	res := make(linalg.Vector, 3)
+	for i := range res {
+		res[i] = s[i] + lorenzStep/6*(k1[i]+2*k2[i]+2*k3[i]+k4[i])
+	}
+	return res
+}
+
+func lorenzDerivative(s linalg.Vector) linalg.Vector {
+	return linalg.Vector{
+		lorenzSigma * (s[1] - s[0]),
+		s[0]*(lorenzRho-s[2]) - s[1],
+		s[0]*s[1] - lorenzBeta*s[2],
+	}
+}
+
+func lorenzOffset(s, d linalg.Vector, scale float64) linalg.Vector {
+	res := make(linalg.Vector, 3)
+	for i := range res {
+		res[i] = s[i] + d[i]*scale
+	}
+	return res
+}
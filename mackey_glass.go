@@ -0,0 +1,93 @@
+package seqtasks
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+)
+
+const (
+	mackeyGlassBeta     = 0.2
+	mackeyGlassGamma    = 0.1
+	mackeyGlassExponent = 10
+	mackeyGlassStep     = 0.1
+	mackeyGlassSubsteps = 10
+	mackeyGlassWashout  = 200
+)
+
+// MackeyGlassTask requires the model to forecast the
+// Mackey-Glass time series, a chaotic delay differential
+// equation.
+//
+// At each timestep, the model is given the current value
+// of the series and must predict the value Horizon
+// timesteps later.
+// Timesteps are one time unit apart, and values are
+// scaled to lie roughly between 0 and 1.
+type MackeyGlassTask struct {
+	// Tau is the delay of the equation.
+	// The series is chaotic for values above about 16.8,
+	// and 17 is a common choice.
+	Tau float64
+
+	// SeqLen is the length of test sequences.
+	SeqLen int
+
+	// Horizon is the number of timesteps ahead which the
+	// model must predict.
+	Horizon int
+}
+
+// InputSize returns 1, since the series is scalar.
+func (m *MackeyGlassTask) InputSize() int {
+	return 1
+}
+
+// OutputSize returns 1, since the series is scalar.
+func (m *MackeyGlassTask) OutputSize() int {
+	return 1
+}
+
+// NewSamples creates a set of samples, each starting at a
+// random point on the attractor.
+func (m *MackeyGlassTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		res = append(res, forecastSample(m.series(), m.Horizon))
+	}
+	return res
+}
+
+// Score returns 1 minus the normalized root-mean-square
+// error of the model's predictions.
+// A perfect model scores 1, and a model which always
+// predicts the mean scores 0.
+func (m *MackeyGlassTask) Score(model Model, batchSize, batchCount int) float64 {
+	return 1 - nrmseScore(m, model, batchSize, batchCount)
+}
+
+// series integrates the equation from a random initial
+// history and returns m.SeqLen+m.Horizon points.
+func (m *MackeyGlassTask) series() []linalg.Vector {
+	delay := int(m.Tau / mackeyGlassStep)
+	history := make([]float64, delay+1)
+	for i := range history {
+		history[i] = 0.5 + rand.Float64()
+	}
+
+	var res []linalg.Vector
+	totalSteps := (mackeyGlassWashout + m.SeqLen + m.Horizon) * mackeyGlassSubsteps
+	for step := 0; step < totalSteps; step++ {
+		x := history[len(history)-1]
+		xTau := history[len(history)-delay-1]
+		dx := mackeyGlassBeta*xTau/(1+math.Pow(xTau, mackeyGlassExponent)) -
+			mackeyGlassGamma*x
+		history = append(history[1:], x+mackeyGlassStep*dx)
+		if step%mackeyGlassSubsteps == 0 && step/mackeyGlassSubsteps >= mackeyGlassWashout {
+			res = append(res, []float64{x / 1.5})
+		}
+	}
+	return res
+}
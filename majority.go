@@ -0,0 +1,103 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// MajorityTask feeds the model a stream of symbols and
+// requires it to output the symbol which has occurred the
+// most times.
+//
+// Ties are resolved in favor of the symbol which reached
+// the tied count first.
+type MajorityTask struct {
+	// SymbolCount is the number of symbols in the
+	// alphabet.
+	SymbolCount int
+
+	// MinLen is the minimum length of the stream.
+	MinLen int
+
+	// MaxLen is the maximum length of the stream.
+	MaxLen int
+
+	// Continuous determines when the model must output
+	// the majority symbol.
+	//
+	// If false, the stream is followed by an end marker,
+	// and the model must only output the majority symbol
+	// at the end marker.
+	//
+	// If true, the model must output the majority symbol
+	// (so far) at every timestep.
+	Continuous bool
+}
+
+// InputSize returns the number of symbols plus one, since
+// there is an extra input for the end marker.
+func (m *MajorityTask) InputSize() int {
+	return m.SymbolCount + 1
+}
+
+// OutputSize returns the number of symbols, since the
+// model must classify the stream as one of the symbols.
+func (m *MajorityTask) OutputSize() int {
+	return m.SymbolCount
+}
+
+// NewSamples creates a set of samples.
+func (m *MajorityTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroOut := make(linalg.Vector, m.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		counts := make([]int, m.SymbolCount)
+		leader := -1
+		streamLen := rand.Intn(m.MaxLen-m.MinLen+1) + m.MinLen
+		for j := 0; j < streamLen; j++ {
+			symbol := rand.Intn(m.SymbolCount)
+			counts[symbol]++
+			if leader < 0 || counts[symbol] > counts[leader] {
+				leader = symbol
+			}
+			inVec := make(linalg.Vector, m.InputSize())
+			inVec[symbol] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			if m.Continuous {
+				outVec := make(linalg.Vector, m.OutputSize())
+				outVec[leader] = 1
+				sample.Outputs = append(sample.Outputs, outVec)
+			} else {
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			}
+		}
+		if !m.Continuous {
+			inVec := make(linalg.Vector, m.InputSize())
+			inVec[m.SymbolCount] = 1
+			outVec := make(linalg.Vector, m.OutputSize())
+			outVec[leader] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			sample.Outputs = append(sample.Outputs, outVec)
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correctly classified
+// timesteps, where the model's largest output is taken
+// to be its classification.
+// If m.Continuous is false, only the end marker's output
+// is counted.
+func (m *MajorityTask) Score(model Model, batchSize, batchCount int) float64 {
+	return argmaxTailScore(m, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		if m.Continuous {
+			return 0
+		}
+		return len(s) - 1
+	})
+}
@@ -30,6 +30,12 @@ type MatchMultiTask struct {
 	// The higher CloseProb, the lest nested tags input strings
 	// are likely to have.
 	CloseProb float64
+
+	// Feedback, if set, makes the task autoregressive.
+	// While the model outputs the closing tags, each input
+	// contains the previous closing tag, as given by
+	// FeedbackInput.
+	Feedback bool
 }
 
 // InputSize returns the number of input symbols into the model,
@@ -75,27 +81,52 @@ func (m *MatchMultiTask) NewSamples(n int) sgd.SampleSet {
 		}
 		sample.Outputs = append(sample.Outputs, zeroOut)
 		sample.Inputs = append(sample.Inputs, inDelimiter)
+		tailIn := zeroIn
 		for j := len(symbolStack) - 1; j >= 0; j-- {
 			symbol := symbolStack[j]
 			outVec := make(linalg.Vector, m.OutputSize())
 			outVec[symbol] = 1
+			sample.Inputs = append(sample.Inputs, tailIn)
 			sample.Outputs = append(sample.Outputs, outVec)
-			sample.Inputs = append(sample.Inputs, zeroIn)
+			if m.Feedback {
+				tailIn = m.FeedbackInput(outVec)
+			}
 		}
+		sample.Inputs = append(sample.Inputs, tailIn)
 		sample.Outputs = append(sample.Outputs, outDelimiter)
-		sample.Inputs = append(sample.Inputs, zeroIn)
 		res = append(res, sample)
 	}
 	return res
 }
 
 func (m *MatchMultiTask) Score(model Model, batchSize, batchCount int) float64 {
-	return roundedBinaryTailScore(m, model, batchSize, batchCount, func(s []linalg.Vector) int {
-		for i, x := range s {
-			if x[len(x)-1] == 1 {
-				return i + 1
-			}
+	return roundedBinaryTailScore(m, model, batchSize, batchCount, m.TailStart)
+}
+
+// TailStart returns the index of the first timestep at
+// which the model must output the closing tags.
+func (m *MatchMultiTask) TailStart(inputs []linalg.Vector) int {
+	for i, x := range inputs {
+		if x[len(x)-1] == 1 {
+			return i + 1
 		}
-		panic("no tail found")
-	})
+	}
+	panic("no tail found")
+}
+
+// FeedbackInput returns an input which closes the tag type
+// with the largest output.
+// If the end symbol has the largest output, the input is
+// all zeroes.
+func (m *MatchMultiTask) FeedbackInput(output linalg.Vector) linalg.Vector {
+	res := make(linalg.Vector, m.InputSize())
+	if symbol := maxIdx(output); symbol < m.TypeCount {
+		res[m.TypeCount+symbol] = 1
+	}
+	return res
+}
+
+// Autoregressive returns m.Feedback.
+func (m *MatchMultiTask) Autoregressive() bool {
+	return m.Feedback
 }
@@ -11,35 +11,94 @@ import (
 
 // MNISTTask requires the model to classify handwritten
 // digits, given a string of pixels comprising an image.
+//
+// By default, the model is fed one pixel per timestep, in
+// raster order.
+//
+// The data sets needn't contain digits.
+// Any MNIST-format data set, such as one loaded with
+// LoadIDXDataSet, can be used.
 type MNISTTask struct {
 	Training mnist.DataSet
 	Testing  mnist.DataSet
+
+	// ClassCount is the number of classes in the data sets.
+	// If this is 0, it is one more than the largest label
+	// in the data sets, as computed by DataSetClassCount.
+	// The labels are only scanned once, so the data sets
+	// should not be modified afterwards.
+	ClassCount int
+
+	// PixelsPerStep is the number of pixels fed to the model
+	// at each timestep.
+	// For instance, setting this to 28 presents images
+	// row-by-row.
+	// If the number of pixels is not divisible by
+	// PixelsPerStep, the last timestep is padded with zeroes.
+	// If this is 0, one pixel is used per timestep.
+	PixelsPerStep int
+
+	// Permutation, if non-nil, specifies the order in which
+	// pixels are fed to the model.
+	// The i-th pixel fed to the model is the pixel at index
+	// Permutation[i] in raster order.
+	// This can be used to create the permuted MNIST task.
+	Permutation []int
+
+	detectedClassCount int
+}
+
+// NewPixelPermutation creates a random permutation of
+// pixel indices for MNISTTask.Permutation.
+// The same seed always yields the same permutation.
+func NewPixelPermutation(seed int64, pixelCount int) []int {
+	return rand.New(rand.NewSource(seed)).Perm(pixelCount)
 }
 
-// InputSize returns 2, since there is a pixel input and
-// an "end-of-digit" input.
+// InputSize returns one more than the number of pixels per
+// timestep, since there is an extra "end-of-digit" input.
 func (m *MNISTTask) InputSize() int {
-	return 2
+	return m.pixelsPerStep() + 1
 }
 
-// OutputSize returns 10, since the model needs to be
-// able to choose between 10 digit classes.
+// OutputSize returns the number of classes, since the
+// model needs to be able to choose between them.
 func (m *MNISTTask) OutputSize() int {
-	return 10
+	if m.ClassCount != 0 {
+		return m.ClassCount
+	}
+	if m.detectedClassCount == 0 {
+		m.detectedClassCount = DataSetClassCount(m.Training, m.Testing)
+	}
+	return m.detectedClassCount
+}
+
+// DataSetClassCount returns one more than the largest label
+// in any of the data sets.
+func DataSetClassCount(sets ...mnist.DataSet) int {
+	var maxLabel int
+	for _, set := range sets {
+		for _, sample := range set.Samples {
+			if sample.Label > maxLabel {
+				maxLabel = sample.Label
+			}
+		}
+	}
+	return maxLabel + 1
 }
 
 // NewSamples creates a list of training sample sequences.
 func (m *MNISTTask) NewSamples(n int) sgd.SampleSet {
 	var res sgd.SliceSampleSet
+	classCount := m.OutputSize()
 	for i := 0; i < n; i++ {
 		var resSample seqtoseq.Sample
 		sample := m.Training.Samples[rand.Intn(len(m.Training.Samples))]
-		for _, x := range sample.Intensities {
-			resSample.Inputs = append(resSample.Inputs, []float64{x, 0})
-			resSample.Outputs = append(resSample.Outputs, make(linalg.Vector, 10))
+		resSample.Inputs = m.inputSequence(sample)
+		for j := 1; j < len(resSample.Inputs); j++ {
+			resSample.Outputs = append(resSample.Outputs, make(linalg.Vector, classCount))
 		}
-		resSample.Inputs = append(resSample.Inputs, []float64{0, 1})
-		outVec := make(linalg.Vector, 10)
+		outVec := make(linalg.Vector, classCount)
 		outVec[sample.Label] = 1
 		resSample.Outputs = append(resSample.Outputs, outVec)
 		res = append(res, resSample)
@@ -57,12 +116,7 @@ func (m *MNISTTask) Score(model Model, batchSize, batchCount int) float64 {
 		for j := 0; j < batchSize; j++ {
 			sample := m.Testing.Samples[rand.Intn(len(m.Testing.Samples))]
 			labels = append(labels, sample.Label)
-			var in []linalg.Vector
-			for _, x := range sample.Intensities {
-				in = append(in, []float64{x, 0})
-			}
-			in = append(in, []float64{0, 1})
-			sequences = append(sequences, in)
+			sequences = append(sequences, m.inputSequence(sample))
 		}
 		outs := model.Run(sequences)
 		for j, label := range labels {
@@ -75,6 +129,32 @@ func (m *MNISTTask) Score(model Model, batchSize, batchCount int) float64 {
 	return float64(correct) / float64(batchSize*batchCount)
 }
 
+// inputSequence creates the input sequence for an image,
+// including the trailing "end-of-digit" timestep.
+func (m *MNISTTask) inputSequence(sample mnist.Sample) []linalg.Vector {
+	stepSize := m.pixelsPerStep()
+	var res []linalg.Vector
+	for i, x := range sample.Intensities {
+		if i%stepSize == 0 {
+			res = append(res, make(linalg.Vector, stepSize+1))
+		}
+		if m.Permutation != nil {
+			x = sample.Intensities[m.Permutation[i]]
+		}
+		res[len(res)-1][i%stepSize] = x
+	}
+	end := make(linalg.Vector, stepSize+1)
+	end[stepSize] = 1
+	return append(res, end)
+}
+
+func (m *MNISTTask) pixelsPerStep() int {
+	if m.PixelsPerStep == 0 {
+		return 1
+	}
+	return m.PixelsPerStep
+}
+
 func maxIdx(v linalg.Vector) int {
 	maxVal := v[0]
 	maxIdx := 0
@@ -0,0 +1,139 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+)
+
+// MixtureTask combines several tasks into one, so that a
+// single model can be trained on all of them at once.
+//
+// Each input begins with a one-hot vector indicating which
+// sub-task the sequence is from, followed by the sub-task's
+// input, padded with zeroes to the size of the largest
+// sub-task input.
+// Outputs are likewise padded with zeroes to the size of
+// the largest sub-task output.
+type MixtureTask struct {
+	Tasks []Task
+
+	// Weights determines how often each task is sampled.
+	// The weights needn't sum to 1.
+	// If this is nil, all tasks are equally likely.
+	Weights []float64
+}
+
+// InputSize returns the number of tasks plus the largest
+// input size of any task.
+func (m *MixtureTask) InputSize() int {
+	var res int
+	for _, t := range m.Tasks {
+		if t.InputSize() > res {
+			res = t.InputSize()
+		}
+	}
+	return len(m.Tasks) + res
+}
+
+// OutputSize returns the largest output size of any task.
+func (m *MixtureTask) OutputSize() int {
+	var res int
+	for _, t := range m.Tasks {
+		if t.OutputSize() > res {
+			res = t.OutputSize()
+		}
+	}
+	return res
+}
+
+// NewSamples creates samples from randomly chosen tasks.
+func (m *MixtureTask) NewSamples(n int) sgd.SampleSet {
+	counts := make([]int, len(m.Tasks))
+	for i := 0; i < n; i++ {
+		counts[m.randomTask()]++
+	}
+	var res sgd.SliceSampleSet
+	for taskIdx, subtask := range m.paddedTasks() {
+		if counts[taskIdx] == 0 {
+			continue
+		}
+		samples := subtask.NewSamples(counts[taskIdx])
+		for i := 0; i < samples.Len(); i++ {
+			res = append(res, samples.GetSample(i))
+		}
+	}
+	for i := range res {
+		j := rand.Intn(i + 1)
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// Score returns the weighted average of the sub-task
+// scores, using m.Weights.
+func (m *MixtureTask) Score(model Model, batchSize, batchCount int) float64 {
+	return m.WeightedScore(m.SubtaskScores(model, batchSize, batchCount))
+}
+
+// WeightedScore computes the weighted average of a list of
+// sub-task scores, such as one returned by SubtaskScores.
+func (m *MixtureTask) WeightedScore(subtaskScores []float64) float64 {
+	var score, totalWeight float64
+	for i, s := range subtaskScores {
+		weight := 1.0
+		if m.Weights != nil {
+			weight = m.Weights[i]
+		}
+		score += s * weight
+		totalWeight += weight
+	}
+	return score / totalWeight
+}
+
+// SubtaskScores scores the model on each of the sub-tasks,
+// using the sub-tasks' own Score methods.
+func (m *MixtureTask) SubtaskScores(model Model, batchSize, batchCount int) []float64 {
+	var res []float64
+	for _, subtask := range m.paddedTasks() {
+		res = append(res, subtask.Score(model, batchSize, batchCount))
+	}
+	return res
+}
+
+func (m *MixtureTask) randomTask() int {
+	if m.Weights == nil {
+		return rand.Intn(len(m.Tasks))
+	}
+	var total float64
+	for _, w := range m.Weights {
+		total += w
+	}
+	x := rand.Float64() * total
+	for i, w := range m.Weights {
+		x -= w
+		if x < 0 {
+			return i
+		}
+	}
+	return len(m.Weights) - 1
+}
+
+// paddedTasks wraps each sub-task so that it has the
+// task ID and padding of the mixture.
+func (m *MixtureTask) paddedTasks() []*paddedTask {
+	inSize, outSize := m.InputSize(), m.OutputSize()
+	res := make([]*paddedTask, len(m.Tasks))
+	for i, t := range m.Tasks {
+		prefix := make(linalg.Vector, len(m.Tasks))
+		prefix[i] = 1
+		res[i] = &paddedTask{
+			Task:    t,
+			Prefix:  prefix,
+			Inputs:  inSize,
+			Outputs: outSize,
+		}
+	}
+	return res
+}
@@ -0,0 +1,85 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// NBackTask feeds the model a stream of symbols and
+// requires it to output 1 whenever the current symbol
+// matches the symbol from N timesteps earlier.
+//
+// For the first N timesteps, there is nothing to match
+// against, so the model must output 0.
+type NBackTask struct {
+	// N is the number of timesteps to look back.
+	N int
+
+	// SymbolCount is the number of symbols in the
+	// alphabet.
+	// It must be at least 2.
+	SymbolCount int
+
+	// SeqLen is the length of test sequences.
+	SeqLen int
+
+	// MatchProb is the probability that a symbol (after
+	// the first N) matches the one from N timesteps
+	// earlier.
+	MatchProb float64
+}
+
+// InputSize returns the number of symbols, since each
+// input is a one-hot vector.
+func (n *NBackTask) InputSize() int {
+	return n.SymbolCount
+}
+
+// OutputSize returns 1, since the model only needs to
+// output whether or not there is a match.
+func (n *NBackTask) OutputSize() int {
+	return 1
+}
+
+// NewSamples creates a new set of training samples.
+func (n *NBackTask) NewSamples(count int) sgd.SampleSet {
+	var set sgd.SliceSampleSet
+	for i := 0; i < count; i++ {
+		var seq seqtoseq.Sample
+		symbols := make([]int, n.SeqLen)
+		for j := range symbols {
+			var match bool
+			if j < n.N {
+				symbols[j] = rand.Intn(n.SymbolCount)
+			} else if rand.Float64() < n.MatchProb {
+				symbols[j] = symbols[j-n.N]
+				match = true
+			} else {
+				// Pick any symbol except the matching one.
+				symbols[j] = rand.Intn(n.SymbolCount - 1)
+				if symbols[j] >= symbols[j-n.N] {
+					symbols[j]++
+				}
+			}
+			inVec := make(linalg.Vector, n.SymbolCount)
+			inVec[symbols[j]] = 1
+			seq.Inputs = append(seq.Inputs, inVec)
+			if match {
+				seq.Outputs = append(seq.Outputs, []float64{1})
+			} else {
+				seq.Outputs = append(seq.Outputs, []float64{0})
+			}
+		}
+		set = append(set, seq)
+	}
+	return set
+}
+
+// Score returns the fraction of correct answers the model
+// returns when the model's outputs are rounded to 0 or 1.
+func (n *NBackTask) Score(m Model, batchSize, batchCount int) float64 {
+	return roundedBinaryScore(n, m, batchSize, batchCount)
+}
@@ -0,0 +1,110 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// Noise describes random perturbations to apply to input
+// sequences.
+// The zero value applies no perturbations.
+type Noise struct {
+	// Gaussian is the standard deviation of Gaussian noise
+	// added to every input component.
+	Gaussian float64
+
+	// FlipProb is the probability that a binary input
+	// component (one which is exactly 0 or 1) is flipped.
+	// This is meant for tasks with binary or one-hot inputs.
+	FlipProb float64
+
+	// DropProb is the probability that the input at a
+	// timestep is dropped, i.e. replaced with zeroes.
+	DropProb float64
+}
+
+// Apply returns a perturbed copy of an input sequence.
+func (n *Noise) Apply(seq []linalg.Vector) []linalg.Vector {
+	res := make([]linalg.Vector, len(seq))
+	for t, vec := range seq {
+		res[t] = make(linalg.Vector, len(vec))
+		if rand.Float64() < n.DropProb {
+			continue
+		}
+		for i, x := range vec {
+			if (x == 0 || x == 1) && rand.Float64() < n.FlipProb {
+				x = 1 - x
+			}
+			if n.Gaussian != 0 {
+				x += rand.NormFloat64() * n.Gaussian
+			}
+			res[t][i] = x
+		}
+	}
+	return res
+}
+
+// NoisyTask wraps a Task and perturbs its inputs, making
+// it possible to measure the robustness of a model.
+//
+// The expected outputs are not affected by the noise.
+type NoisyTask struct {
+	Task Task
+
+	// TrainingNoise is applied to the inputs of samples
+	// from NewSamples.
+	TrainingNoise Noise
+
+	// ScoringNoise is applied to the inputs that the
+	// model sees while it is being scored.
+	ScoringNoise Noise
+}
+
+// InputSize returns the input size of the wrapped task.
+func (n *NoisyTask) InputSize() int {
+	return n.Task.InputSize()
+}
+
+// OutputSize returns the output size of the wrapped task.
+func (n *NoisyTask) OutputSize() int {
+	return n.Task.OutputSize()
+}
+
+// NewSamples creates samples with the wrapped task and
+// applies n.TrainingNoise to them.
+func (n *NoisyTask) NewSamples(count int) sgd.SampleSet {
+	samples := n.Task.NewSamples(count)
+	var res sgd.SliceSampleSet
+	for i := 0; i < samples.Len(); i++ {
+		sample := samples.GetSample(i).(seqtoseq.Sample)
+		res = append(res, seqtoseq.Sample{
+			Inputs:  n.TrainingNoise.Apply(sample.Inputs),
+			Outputs: sample.Outputs,
+		})
+	}
+	return res
+}
+
+// Score scores the model on the wrapped task, applying
+// n.ScoringNoise to every input the model sees.
+func (n *NoisyTask) Score(m Model, batchSize, batchCount int) float64 {
+	return n.Task.Score(&noisyModel{Model: m, Noise: &n.ScoringNoise}, batchSize, batchCount)
+}
+
+// A noisyModel applies noise to the inputs of another
+// Model.
+type noisyModel struct {
+	Model
+	Noise *Noise
+}
+
+func (n *noisyModel) Run(inputs [][]linalg.Vector) [][]linalg.Vector {
+	noisy := make([][]linalg.Vector, len(inputs))
+	for i, seq := range inputs {
+		noisy[i] = n.Noise.Apply(seq)
+	}
+	return n.Model.Run(noisy)
+}
@@ -0,0 +1,90 @@
+package seqtasks
+
+import (
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// A paddedTask embeds the inputs and outputs of a task in
+// larger vectors, so that tasks of different sizes can be
+// run by the same model.
+//
+// Each input starts with Prefix, followed by the task's
+// input, padded with zeroes to the size Inputs.
+// Outputs are padded with zeroes to the size Outputs.
+type paddedTask struct {
+	Task    Task
+	Prefix  linalg.Vector
+	Inputs  int
+	Outputs int
+}
+
+func (p *paddedTask) InputSize() int {
+	return p.Inputs
+}
+
+func (p *paddedTask) OutputSize() int {
+	return p.Outputs
+}
+
+func (p *paddedTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	samples := p.Task.NewSamples(n)
+	for i := 0; i < samples.Len(); i++ {
+		sample := samples.GetSample(i).(seqtoseq.Sample)
+		var padded seqtoseq.Sample
+		padded.Inputs = p.padInputs(sample.Inputs)
+		for _, out := range sample.Outputs {
+			padded.Outputs = append(padded.Outputs, padVector(out, p.Outputs))
+		}
+		res = append(res, padded)
+	}
+	return res
+}
+
+// Score scores the model on the underlying task, using
+// the underlying task's Score method.
+func (p *paddedTask) Score(m Model, batchSize, batchCount int) float64 {
+	return p.Task.Score(&paddedModel{Model: m, Task: p}, batchSize, batchCount)
+}
+
+func (p *paddedTask) padInputs(seq []linalg.Vector) []linalg.Vector {
+	var res []linalg.Vector
+	for _, in := range seq {
+		vec := make(linalg.Vector, p.Inputs)
+		copy(vec, p.Prefix)
+		copy(vec[len(p.Prefix):], in)
+		res = append(res, vec)
+	}
+	return res
+}
+
+// A paddedModel runs a model which was trained on a
+// paddedTask on the inputs of the underlying task.
+type paddedModel struct {
+	Model
+	Task *paddedTask
+}
+
+func (p *paddedModel) Run(inputs [][]linalg.Vector) [][]linalg.Vector {
+	var padded [][]linalg.Vector
+	for _, seq := range inputs {
+		padded = append(padded, p.Task.padInputs(seq))
+	}
+	outSize := p.Task.Task.OutputSize()
+	res := p.Model.Run(padded)
+	for _, seq := range res {
+		for i, vec := range seq {
+			seq[i] = vec[:outSize]
+		}
+	}
+	return res
+}
+
+// padVector pads a vector with zeroes to the given size.
+func padVector(v linalg.Vector, size int) linalg.Vector {
+	res := make(linalg.Vector, size)
+	copy(res, v)
+	return res
+}
@@ -24,6 +24,12 @@ type RepeatTask struct {
 	// MaxGap is the maximum number of zeroes between giving
 	// the string and requesting it back.
 	MaxGap int
+
+	// Feedback, if set, makes the task autoregressive.
+	// While the model outputs the string, each input
+	// contains the previous bit of the string in the data
+	// component, as given by FeedbackInput.
+	Feedback bool
 }
 
 // InputSize returns 3, since the first input is for data, the
@@ -61,7 +67,11 @@ func (r *RepeatTask) NewSamples(n int) sgd.SampleSet {
 		sample.Outputs = append(sample.Outputs, []float64{0})
 		for j := 0; j < stringLen; j++ {
 			out := sample.Inputs[j][0]
-			sample.Inputs = append(sample.Inputs, []float64{0, 0, 0})
+			inVec := linalg.Vector{0, 0, 0}
+			if r.Feedback && j > 0 {
+				inVec = r.FeedbackInput(sample.Outputs[len(sample.Outputs)-1])
+			}
+			sample.Inputs = append(sample.Inputs, inVec)
 			sample.Outputs = append(sample.Outputs, []float64{out})
 		}
 		res = append(res, sample)
@@ -74,12 +84,30 @@ func (r *RepeatTask) NewSamples(n int) sgd.SampleSet {
 // up to the recall phase.
 // Output values from the model are rounded to 0 or 1.
 func (r *RepeatTask) Score(m Model, batchSize, batchCount int) float64 {
-	return roundedBinaryTailScore(r, m, batchSize, batchCount, func(s []linalg.Vector) int {
-		for i, x := range s {
-			if x[2] == 1 {
-				return i + 1
-			}
+	return roundedBinaryTailScore(r, m, batchSize, batchCount, r.TailStart)
+}
+
+// TailStart returns the index of the first timestep at
+// which the model must output the string.
+func (r *RepeatTask) TailStart(inputs []linalg.Vector) int {
+	for i, x := range inputs {
+		if x[2] == 1 {
+			return i + 1
 		}
-		panic("no tail found")
-	})
+	}
+	panic("no tail found")
+}
+
+// FeedbackInput rounds an output to 0 or 1 and returns an
+// input with the result in the data component.
+func (r *RepeatTask) FeedbackInput(output linalg.Vector) linalg.Vector {
+	if output[0] >= 0.5 {
+		return linalg.Vector{1, 0, 0}
+	}
+	return linalg.Vector{0, 0, 0}
+}
+
+// Autoregressive returns r.Feedback.
+func (r *RepeatTask) Autoregressive() bool {
+	return r.Feedback
 }
@@ -0,0 +1,88 @@
+package seqtasks
+
+import (
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// A retimer changes the timing of sequences by inserting
+// or repeating timesteps.
+type retimer interface {
+	// sources returns, for each timestep of a retimed
+	// sequence, the index of the original timestep it came
+	// from, or -1 for an inserted timestep.
+	// Original timesteps must appear in order.
+	sources(seqLen int) []int
+
+	// insertedInput returns the input for an inserted
+	// timestep.
+	insertedInput(inSize int) linalg.Vector
+}
+
+// retimeSamples retimes a set of samples.
+// Repeated timesteps repeat their expected outputs, while
+// inserted timesteps expect zero outputs.
+func retimeSamples(r retimer, samples sgd.SampleSet, inSize, outSize int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < samples.Len(); i++ {
+		sample := samples.GetSample(i).(seqtoseq.Sample)
+		var newSample seqtoseq.Sample
+		for _, src := range r.sources(len(sample.Inputs)) {
+			if src < 0 {
+				newSample.Inputs = append(newSample.Inputs, r.insertedInput(inSize))
+				newSample.Outputs = append(newSample.Outputs, make(linalg.Vector, outSize))
+			} else {
+				newSample.Inputs = append(newSample.Inputs, sample.Inputs[src])
+				newSample.Outputs = append(newSample.Outputs, sample.Outputs[src])
+			}
+		}
+		res = append(res, newSample)
+	}
+	return res
+}
+
+// A retimedModel retimes the sequences passed to another
+// Model, and maps the outputs back to the original
+// timesteps.
+//
+// This makes it possible to score a model on retimed
+// sequences using the Score method of the original task,
+// whose notion of where a sequence's tail starts remains
+// correct.
+type retimedModel struct {
+	Model
+	Retimer retimer
+}
+
+// Run retimes the inputs and runs the wrapped model.
+// The output for each original timestep is the model's
+// output at the last retimed timestep that came from it.
+func (r *retimedModel) Run(inputs [][]linalg.Vector) [][]linalg.Vector {
+	var retimed [][]linalg.Vector
+	var allSources [][]int
+	for _, seq := range inputs {
+		sources := r.Retimer.sources(len(seq))
+		var newSeq []linalg.Vector
+		for _, src := range sources {
+			if src < 0 {
+				newSeq = append(newSeq, r.Retimer.insertedInput(len(seq[0])))
+			} else {
+				newSeq = append(newSeq, seq[src])
+			}
+		}
+		retimed = append(retimed, newSeq)
+		allSources = append(allSources, sources)
+	}
+	outputs := r.Model.Run(retimed)
+	res := make([][]linalg.Vector, len(inputs))
+	for i, seq := range inputs {
+		res[i] = make([]linalg.Vector, len(seq))
+		for j, src := range allSources[i] {
+			if src >= 0 {
+				res[i][src] = outputs[i][j]
+			}
+		}
+	}
+	return res
+}
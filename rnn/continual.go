@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log"
+
+	"github.com/unixpickle/seqtasks"
+)
+
+// A ContinualTask runs a continual learning benchmark,
+// training models on several tasks in a row.
+type ContinualTask struct {
+	Name      string
+	Benchmark *seqtasks.ContinualBenchmark
+	Models    map[string]seqtasks.Model
+}
+
+func (c *ContinualTask) Run(modelName string) {
+	model, ok := c.Models[modelName]
+	if !ok {
+		log.Printf("Model \"%s\" not implemented for task \"%s\"", modelName, c.Name)
+		return
+	}
+	log.Printf("Running task \"%s\" with model \"%s\"", c.Name, modelName)
+	matrix := c.Benchmark.Run(model, func(task, epoch int, score float64) {
+		log.Printf("task %d epoch %d: score=%f", task, epoch, score)
+	})
+	log.Printf("Forgetting matrix (row i is after training on task i):")
+	for i, row := range matrix {
+		log.Printf("%d: %v", i, row)
+	}
+}
+
+var Continual = &ContinualTask{
+	Name: "Continual",
+	Benchmark: &seqtasks.ContinualBenchmark{
+		Tasks: []seqtasks.Task{
+			&seqtasks.RepeatTask{
+				MinString: 2,
+				MaxString: 5,
+				MinGap:    0,
+				MaxGap:    6,
+			},
+			&seqtasks.MatchOpenTask{
+				MinLen:  1,
+				MaxLen:  15,
+				MaxOpen: 6,
+			},
+			&seqtasks.AdditionTask{MaxDigits: 3, Base: 4},
+		},
+		MaxEpochs:    100,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	Models: map[string]seqtasks.Model{
+		"lstm":       NewLSTM(8, 100, 1, 100, 4),
+		"stack":      NewStructLSTM(Structs["stack"], 8, 40, 1, 40, 4),
+		"queue":      NewStructLSTM(Structs["queue"], 8, 40, 1, 40, 4),
+		"multistack": NewStructLSTM(Structs["multistack"], 8, 40, 1, 40, 4),
+		"multiqueue": NewStructLSTM(Structs["multiqueue"], 8, 40, 1, 40, 4),
+		"irnn":       NewIRNN(8, 40, 3, 40, 4, 1),
+		"nprnn":      NewNPRNN(8, 40, 3, 40, 4),
+		"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 8, 4, 40),
+		"hebbnet":    NewHebbNet(8, 20, 2, 40, 4),
+		"cwrnn":      NewCWRNN(false, 8, 4, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+		"cwrnnfc":    NewCWRNN(true, 8, 4, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+		"rbf":        NewRBF(8, 40, 3, 40, 4),
+	},
+}
@@ -17,6 +17,8 @@ func main() {
 		for _, task := range Tasks {
 			fmt.Fprintln(os.Stderr, " -", task.Name)
 		}
+		fmt.Fprintln(os.Stderr, " -", Continual.Name)
+		fmt.Fprintln(os.Stderr, " -", Transfer.Name)
 		fmt.Fprintln(os.Stderr)
 		os.Exit(1)
 	}
@@ -27,6 +29,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if len(os.Args) == 3 && os.Args[2] == Continual.Name {
+		Continual.Run(model)
+		return
+	} else if len(os.Args) == 3 && os.Args[2] == Transfer.Name {
+		Transfer.Run(model)
+		return
+	}
+
 	tasks := Tasks
 	if len(os.Args) == 3 {
 		for _, t := range tasks {
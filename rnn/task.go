@@ -29,14 +29,26 @@ func (t *Task) Run(modelName string) {
 	for i := 0; i < t.MaxEpochs; i++ {
 		samples := t.Task.NewSamples(t.TrainingSize)
 		model.Train(samples)
-		score := t.Task.Score(model, t.TestingBatch, t.TestingCount)
-		log.Printf("epoch %d: score=%f", i, score)
+		var score float64
+		if mixture, ok := t.Task.(*seqtasks.MixtureTask); ok {
+			subScores := mixture.SubtaskScores(model, t.TestingBatch, t.TestingCount)
+			score = mixture.WeightedScore(subScores)
+			log.Printf("epoch %d: score=%f subtasks=%v", i, score, subScores)
+		} else {
+			score = t.Task.Score(model, t.TestingBatch, t.TestingCount)
+			log.Printf("epoch %d: score=%f", i, score)
+		}
 		if score >= t.MaxScore {
 			break
 		}
 	}
 }
 
+var (
+	mnistTraining = mnist.LoadTrainingDataSet()
+	mnistTesting  = mnist.LoadTestingDataSet()
+)
+
 var Tasks = []*Task{
 	{
 		Name: "XOR last",
@@ -220,11 +232,685 @@ var Tasks = []*Task{
 		TestingBatch: 20,
 		TestingCount: 100,
 	},
+	{
+		Name: "Expression",
+		Task: &seqtasks.ExpressionTask{
+			Base:      4,
+			MaxDigits: 2,
+			MaxDepth:  2,
+			NestProb:  0.5,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4+6, 100, 2, 100, 4+2).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 4+6, 40, 1, 40, 4+2).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 4+6, 40, 1, 40, 4+2).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 4+6, 40, 1, 40, 4+2).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4+6, 40, 1, 40, 4+2).UseSoftmax(),
+			"irnn":       NewIRNN(4+6, 40, 3, 40, 4+2, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(4+6, 40, 3, 40, 4+2).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4+6, 4+2, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(4+6, 20, 2, 40, 4+2).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 4+6, 4+2, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 4+6, 4+2, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(4+6, 40, 3, 40, 4+2).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 3000,
+		TestingBatch: 20,
+		TestingCount: 100,
+	},
+	{
+		Name: "Execute",
+		Task: &seqtasks.ExecuteTask{
+			MaxDigits:     2,
+			MaxNesting:    1,
+			MaxStatements: 2,
+			MaxIterations: 3,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(46, 100, 2, 100, 11).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 46, 40, 1, 40, 11).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 46, 40, 1, 40, 11).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 46, 40, 1, 40, 11).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 46, 40, 1, 40, 11).UseSoftmax(),
+			"irnn":       NewIRNN(46, 40, 3, 40, 11, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(46, 40, 3, 40, 11).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 46, 11, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(46, 20, 2, 40, 11).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 46, 11, []int{1, 2, 4, 8, 16},
+				[]int{20, 20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 46, 11, []int{1, 2, 4, 8, 16},
+				[]int{20, 20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(46, 40, 3, 40, 11).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 3000,
+		TestingBatch: 20,
+		TestingCount: 100,
+	},
+	{
+		Name: "RPN",
+		Task: &seqtasks.RPNTask{
+			Base:       4,
+			MinLen:     1,
+			MaxLen:     10,
+			MaxStack:   6,
+			FinalStack: true,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4+6, 100, 2, 100, 4+1).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 4+6, 40, 1, 40, 4+1).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 4+6, 40, 1, 40, 4+1).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 4+6, 40, 1, 40, 4+1).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4+6, 40, 1, 40, 4+1).UseSoftmax(),
+			"irnn":       NewIRNN(4+6, 40, 3, 40, 4+1, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(4+6, 40, 3, 40, 4+1).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4+6, 4+1, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(4+6, 20, 2, 40, 4+1).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 4+6, 4+1, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 4+6, 4+1, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(4+6, 40, 3, 40, 4+1).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 3000,
+		TestingBatch: 20,
+		TestingCount: 100,
+	},
+	{
+		Name: "N-back",
+		Task: &seqtasks.NBackTask{
+			N:           3,
+			SymbolCount: 4,
+			SeqLen:      30,
+			MatchProb:   0.3,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4, 40, 1, 40, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 4, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 4, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 4, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4, 40, 1, 40, 1),
+			"irnn":       NewIRNN(4, 40, 3, 40, 1, 1),
+			"nprnn":      NewNPRNN(4, 40, 3, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4, 1, 40),
+			"hebbnet":    NewHebbNet(4, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 4, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 4, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(4, 40, 3, 40, 1),
+		},
+		MaxEpochs:    100,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Majority",
+		Task: &seqtasks.MajorityTask{
+			SymbolCount: 3,
+			MinLen:      5,
+			MaxLen:      30,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4, 40, 1, 40, 3).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"irnn":       NewIRNN(4, 40, 3, 40, 3, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(4, 40, 3, 40, 3).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4, 3, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(4, 20, 2, 40, 3).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 4, 3, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 4, 3, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(4, 40, 3, 40, 3).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Threshold",
+		Task: &seqtasks.ThresholdTask{
+			SymbolCount: 3,
+			Threshold:   5,
+			TargetProb:  0.3,
+			MinLen:      5,
+			MaxLen:      30,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4, 40, 1, 40, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 4, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 4, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 4, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4, 40, 1, 40, 1),
+			"irnn":       NewIRNN(4, 40, 3, 40, 1, 1),
+			"nprnn":      NewNPRNN(4, 40, 3, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4, 1, 40),
+			"hebbnet":    NewHebbNet(4, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 4, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 4, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(4, 40, 3, 40, 1),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Mackey-Glass",
+		Task: &seqtasks.MackeyGlassTask{
+			Tau:     17,
+			SeqLen:  100,
+			Horizon: 1,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(1, 40, 1, 40, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 1, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 1, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 1, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 1, 40, 1, 40, 1),
+			"irnn":       NewIRNN(1, 40, 3, 40, 1, 1),
+			"nprnn":      NewNPRNN(1, 40, 3, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 1, 1, 40),
+			"hebbnet":    NewHebbNet(1, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 1, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 1, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(1, 40, 3, 40, 1),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     0.95,
+		TrainingSize: 100,
+		TestingBatch: 10,
+		TestingCount: 10,
+	},
+	{
+		Name: "Lorenz",
+		Task: &seqtasks.LorenzTask{
+			SeqLen:  100,
+			Horizon: 1,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(3, 40, 1, 40, 3),
+			"stack":      NewStructLSTM(Structs["stack"], 3, 40, 1, 40, 3),
+			"queue":      NewStructLSTM(Structs["queue"], 3, 40, 1, 40, 3),
+			"multistack": NewStructLSTM(Structs["multistack"], 3, 40, 1, 40, 3),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 3, 40, 1, 40, 3),
+			"irnn":       NewIRNN(3, 40, 3, 40, 3, 1),
+			"nprnn":      NewNPRNN(3, 40, 3, 40, 3),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 3, 3, 40),
+			"hebbnet":    NewHebbNet(3, 20, 2, 40, 3),
+			"cwrnn":      NewCWRNN(false, 3, 3, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 3, 3, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(3, 40, 3, 40, 3),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     0.95,
+		TrainingSize: 100,
+		TestingBatch: 10,
+		TestingCount: 10,
+	},
+	{
+		Name: "Sine Generation",
+		Task: &seqtasks.SineGenerationTask{
+			Frequencies: []float64{0.02, 0.05, 0.1, 0.2},
+			OneHot:      true,
+			SeqLen:      50,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4, 40, 1, 40, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 4, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 4, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 4, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4, 40, 1, 40, 1),
+			"irnn":       NewIRNN(4, 40, 3, 40, 1, 1),
+			"nprnn":      NewNPRNN(4, 40, 3, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4, 1, 40),
+			"hebbnet":    NewHebbNet(4, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 4, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 4, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(4, 40, 3, 40, 1),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     0.99,
+		TrainingSize: 100,
+		TestingBatch: 10,
+		TestingCount: 10,
+	},
+	{
+		Name: "Sine Classification",
+		Task: &seqtasks.SineClassificationTask{
+			Frequencies: []float64{0.02, 0.05, 0.1, 0.2},
+			Noise:       0.2,
+			SeqLen:      50,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(2, 40, 1, 40, 4).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 2, 40, 1, 40, 4).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 2, 40, 1, 40, 4).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 2, 40, 1, 40, 4).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 2, 40, 1, 40, 4).UseSoftmax(),
+			"irnn":       NewIRNN(2, 40, 3, 40, 4, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(2, 40, 3, 40, 4).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 2, 4, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(2, 20, 2, 40, 4).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 2, 4, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 2, 4, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(2, 40, 3, 40, 4).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Story",
+		Task: &seqtasks.StoryTask{
+			PersonCount:   3,
+			LocationCount: 4,
+			ObjectCount:   3,
+			MinStatements: 2,
+			MaxStatements: 6,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(19, 100, 2, 100, 10).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 19, 40, 1, 40, 10).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 19, 40, 1, 40, 10).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 19, 40, 1, 40, 10).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 19, 40, 1, 40, 10).UseSoftmax(),
+			"irnn":       NewIRNN(19, 40, 3, 40, 10, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(19, 40, 3, 40, 10).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 19, 10, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(19, 20, 2, 40, 10).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 19, 10, []int{1, 2, 4, 8, 16},
+				[]int{20, 20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 19, 10, []int{1, 2, 4, 8, 16},
+				[]int{20, 20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(19, 40, 3, 40, 10).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 1000,
+		TestingBatch: 20,
+		TestingCount: 50,
+	},
+	{
+		Name: "Graph Path",
+		Task: &seqtasks.GraphTask{
+			NodeCount: 5,
+			MinEdges:  3,
+			MaxEdges:  7,
+			Directed:  true,
+			Query:     seqtasks.PathQuery,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(11, 100, 2, 100, 6).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 11, 40, 1, 40, 6).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 11, 40, 1, 40, 6).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 11, 40, 1, 40, 6).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 11, 40, 1, 40, 6).UseSoftmax(),
+			"irnn":       NewIRNN(11, 40, 3, 40, 6, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(11, 40, 3, 40, 6).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 11, 6, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(11, 20, 2, 40, 6).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 11, 6, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 11, 6, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(11, 40, 3, 40, 6).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 1000,
+		TestingBatch: 20,
+		TestingCount: 50,
+	},
+	{
+		Name: "Rule 110",
+		Task: &seqtasks.CellularAutomatonTask{
+			Rule:        110,
+			Width:       10,
+			Generations: 1,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(2, 40, 1, 40, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 2, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 2, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 2, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 2, 40, 1, 40, 1),
+			"irnn":       NewIRNN(2, 40, 3, 40, 1, 1),
+			"nprnn":      NewNPRNN(2, 40, 3, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 2, 1, 40),
+			"hebbnet":    NewHebbNet(2, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 2, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 2, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(2, 40, 3, 40, 1),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Grid Walk",
+		Task: &seqtasks.GridWalkTask{
+			Width:  5,
+			Height: 5,
+			MinLen: 1,
+			MaxLen: 20,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(5, 40, 1, 40, 10),
+			"stack":      NewStructLSTM(Structs["stack"], 5, 40, 1, 40, 10),
+			"queue":      NewStructLSTM(Structs["queue"], 5, 40, 1, 40, 10),
+			"multistack": NewStructLSTM(Structs["multistack"], 5, 40, 1, 40, 10),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 5, 40, 1, 40, 10),
+			"irnn":       NewIRNN(5, 40, 3, 40, 10, 1),
+			"nprnn":      NewNPRNN(5, 40, 3, 40, 10),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 5, 10, 40),
+			"hebbnet":    NewHebbNet(5, 20, 2, 40, 10),
+			"cwrnn":      NewCWRNN(false, 5, 10, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 5, 10, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(5, 40, 3, 40, 10),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Noisy Repeat",
+		Task: &seqtasks.NoisyTask{
+			Task: &seqtasks.RepeatTask{
+				MinString: 2,
+				MaxString: 5,
+				MinGap:    0,
+				MaxGap:    6,
+			},
+			ScoringNoise: seqtasks.Noise{FlipProb: 0.05},
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(3, 100, 1, 100, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 3, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 3, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 3, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 3, 40, 1, 40, 1),
+			"irnn":       NewIRNN(3, 100, 1, 100, 1, 0.1),
+			"nprnn":      NewNPRNN(3, 40, 1, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 3, 1, 40),
+			"hebbnet":    NewHebbNet(3, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 3, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 3, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(3, 40, 1, 40, 1),
+		},
+		MaxEpochs:    100,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Mixture",
+		Task: &seqtasks.MixtureTask{
+			Tasks: []seqtasks.Task{
+				&seqtasks.XORLastTask{SeqLen: 50},
+				&seqtasks.RepeatTask{
+					MinString: 2,
+					MaxString: 5,
+					MinGap:    0,
+					MaxGap:    6,
+				},
+				&seqtasks.MatchOpenTask{
+					MinLen:  1,
+					MaxLen:  15,
+					MaxOpen: 6,
+				},
+			},
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(6, 100, 1, 100, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 6, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 6, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 6, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 6, 40, 1, 40, 1),
+			"irnn":       NewIRNN(6, 40, 3, 40, 1, 1),
+			"nprnn":      NewNPRNN(6, 40, 3, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 6, 1, 40),
+			"hebbnet":    NewHebbNet(6, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 6, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 6, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(6, 40, 3, 40, 1),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Concat Repeat",
+		Task: &seqtasks.ConcatTask{
+			Task: &seqtasks.RepeatTask{
+				MinString: 2,
+				MaxString: 5,
+				MinGap:    0,
+				MaxGap:    6,
+			},
+			Count: 3,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(3, 100, 1, 100, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 3, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 3, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 3, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 3, 40, 1, 40, 1),
+			"irnn":       NewIRNN(3, 100, 1, 100, 1, 0.1),
+			"nprnn":      NewNPRNN(3, 40, 1, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 3, 1, 40),
+			"hebbnet":    NewHebbNet(3, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 3, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 3, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(3, 40, 1, 40, 1),
+		},
+		MaxEpochs:    100,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Bandit",
+		Task: &seqtasks.EnvironmentTask{
+			NewEnvironment: func() seqtasks.Environment {
+				return &seqtasks.BanditEnvironment{ArmCount: 2, Pulls: 20}
+			},
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(3, 40, 1, 40, 2).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 3, 40, 1, 40, 2).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 3, 40, 1, 40, 2).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 3, 40, 1, 40, 2).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 3, 40, 1, 40, 2).UseSoftmax(),
+			"irnn":       NewIRNN(3, 40, 3, 40, 2, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(3, 40, 3, 40, 2).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 3, 2, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(3, 20, 2, 40, 2).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 3, 2, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 3, 2, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(3, 40, 3, 40, 2).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     0.58,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "T-maze",
+		Task: &seqtasks.EnvironmentTask{
+			NewEnvironment: func() seqtasks.Environment {
+				return &seqtasks.TMazeEnvironment{Length: 10}
+			},
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4, 40, 1, 40, 3).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4, 40, 1, 40, 3).UseSoftmax(),
+			"irnn":       NewIRNN(4, 40, 3, 40, 3, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(4, 40, 3, 40, 3).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4, 3, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(4, 20, 2, 40, 3).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 4, 3, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 4, 3, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(4, 40, 3, 40, 3).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Free-Running Addition",
+		Task: &seqtasks.FreeRunningTask{
+			Task: &seqtasks.AdditionTask{MaxDigits: 3, Base: 4, Feedback: true},
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(5, 40, 3, 40, 4).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 5, 40, 1, 40, 4).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 5, 40, 1, 40, 4).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 5, 40, 1, 40, 4).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 5, 40, 1, 40, 4).UseSoftmax(),
+			"irnn":       NewIRNN(5, 40, 3, 40, 4, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(5, 40, 3, 40, 4).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 5, 4, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(5, 40, 3, 40, 4).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 5, 4, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 5, 4, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(5, 40, 1, 40, 4).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 500,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Free-Running Repeat",
+		Task: &seqtasks.FreeRunningTask{
+			Task: &seqtasks.RepeatTask{
+				MinString: 2,
+				MaxString: 5,
+				MinGap:    0,
+				MaxGap:    6,
+				Feedback:  true,
+			},
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(3, 100, 1, 100, 1),
+			"stack":      NewStructLSTM(Structs["stack"], 3, 40, 1, 40, 1),
+			"queue":      NewStructLSTM(Structs["queue"], 3, 40, 1, 40, 1),
+			"multistack": NewStructLSTM(Structs["multistack"], 3, 40, 1, 40, 1),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 3, 40, 1, 40, 1),
+			"irnn":       NewIRNN(3, 100, 1, 100, 1, 0.1),
+			"nprnn":      NewNPRNN(3, 40, 1, 40, 1),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 3, 1, 40),
+			"hebbnet":    NewHebbNet(3, 20, 2, 40, 1),
+			"cwrnn":      NewCWRNN(false, 3, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"cwrnnfc":    NewCWRNN(true, 3, 1, []int{1, 2, 4, 8}, []int{20, 20, 20, 20}),
+			"rbf":        NewRBF(3, 40, 1, 40, 1),
+		},
+		MaxEpochs:    100,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Variable Addition",
+		Task: &seqtasks.VariableAdditionTask{MaxDigits: 3, Base: 4},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(5, 40, 3, 40, 5).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 5, 40, 1, 40, 5).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 5, 40, 1, 40, 5).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 5, 40, 1, 40, 5).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 5, 40, 1, 40, 5).UseSoftmax(),
+			"irnn":       NewIRNN(5, 40, 3, 40, 5, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(5, 40, 3, 40, 5).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 5, 5, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(5, 40, 3, 40, 5).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 5, 5, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 5, 5, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(5, 40, 1, 40, 5).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 500,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	{
+		Name: "Run Length",
+		Task: &seqtasks.RunLengthTask{
+			SymbolCount: 3,
+			MinLen:      1,
+			MaxLen:      8,
+			MaxRun:      4,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(4, 40, 3, 40, 8).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 4, 40, 1, 40, 8).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 4, 40, 1, 40, 8).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 4, 40, 1, 40, 8).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 4, 40, 1, 40, 8).UseSoftmax(),
+			"irnn":       NewIRNN(4, 40, 3, 40, 8, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(4, 40, 3, 40, 8).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 4, 8, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(4, 40, 3, 40, 8).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 4, 8, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 4, 8, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(4, 40, 1, 40, 8).UseSoftmax(),
+		},
+		MaxEpochs:    1000,
+		MaxScore:     1,
+		TrainingSize: 500,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
 	{
 		Name: "MNIST",
 		Task: &seqtasks.MNISTTask{
-			Training: mnist.LoadTrainingDataSet(),
-			Testing:  mnist.LoadTestingDataSet(),
+			Training: mnistTraining,
+			Testing:  mnistTesting,
 		},
 		Models: map[string]seqtasks.Model{
 			"lstm":       NewLSTM(2, 100, 2, 100, 10).UseSoftmax(),
@@ -248,4 +934,92 @@ var Tasks = []*Task{
 		TestingBatch: 1,
 		TestingCount: 100,
 	},
+	{
+		Name: "pMNIST",
+		Task: &seqtasks.MNISTTask{
+			Training:    mnistTraining,
+			Testing:     mnistTesting,
+			Permutation: seqtasks.NewPixelPermutation(1337, 28*28),
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(2, 100, 2, 100, 10).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 2, 40, 1, 40, 10).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 2, 40, 1, 40, 10).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 2, 40, 1, 40, 10).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 2, 40, 1, 40, 10).UseSoftmax(),
+			"irnn":       NewIRNN(2, 40, 3, 40, 10, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(2, 40, 3, 40, 10).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 2, 10, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(2, 20, 2, 40, 10).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 2, 10, []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 512},
+				[]int{20, 20, 20, 20, 20, 20, 20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 2, 10, []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 512},
+				[]int{20, 20, 20, 20, 20, 20, 20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(2, 40, 3, 40, 10).UseSoftmax(),
+		},
+		MaxEpochs:    10000,
+		MaxScore:     1,
+		TrainingSize: 100,
+		TestingBatch: 1,
+		TestingCount: 100,
+	},
+	{
+		Name: "Row MNIST",
+		Task: &seqtasks.MNISTTask{
+			Training:      mnistTraining,
+			Testing:       mnistTesting,
+			PixelsPerStep: 28,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(29, 100, 2, 100, 10).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 29, 40, 1, 40, 10).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 29, 40, 1, 40, 10).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 29, 40, 1, 40, 10).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 29, 40, 1, 40, 10).UseSoftmax(),
+			"irnn":       NewIRNN(29, 40, 3, 40, 10, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(29, 40, 3, 40, 10).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 29, 10, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(29, 20, 2, 40, 10).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 29, 10, []int{1, 2, 4, 8, 16},
+				[]int{20, 20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 29, 10, []int{1, 2, 4, 8, 16},
+				[]int{20, 20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(29, 40, 3, 40, 10).UseSoftmax(),
+		},
+		MaxEpochs:    10000,
+		MaxScore:     1,
+		TrainingSize: 100,
+		TestingBatch: 10,
+		TestingCount: 100,
+	},
+	{
+		Name: "Few-Shot MNIST",
+		Task: &seqtasks.FewShotTask{
+			Training:   mnistTraining,
+			Testing:    mnistTesting,
+			ClassCount: 5,
+			EpisodeLen: 30,
+		},
+		Models: map[string]seqtasks.Model{
+			"lstm":       NewLSTM(28*28+5, 100, 2, 100, 5).UseSoftmax(),
+			"stack":      NewStructLSTM(Structs["stack"], 28*28+5, 40, 1, 40, 5).UseSoftmax(),
+			"queue":      NewStructLSTM(Structs["queue"], 28*28+5, 40, 1, 40, 5).UseSoftmax(),
+			"multistack": NewStructLSTM(Structs["multistack"], 28*28+5, 40, 1, 40, 5).UseSoftmax(),
+			"multiqueue": NewStructLSTM(Structs["multiqueue"], 28*28+5, 40, 1, 40, 5).UseSoftmax(),
+			"irnn":       NewIRNN(28*28+5, 40, 3, 40, 5, 1).UseSoftmax(),
+			"nprnn":      NewNPRNN(28*28+5, 40, 3, 40, 5).UseSoftmax(),
+			"ffstruct":   NewStructFeedforward(Structs["ffstruct"], 28*28+5, 5, 40).UseSoftmax(),
+			"hebbnet":    NewHebbNet(28*28+5, 20, 2, 40, 5).UseSoftmax(),
+			"cwrnn": NewCWRNN(false, 28*28+5, 5, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"cwrnnfc": NewCWRNN(true, 28*28+5, 5, []int{1, 2, 4, 8},
+				[]int{20, 20, 20, 20}).UseSoftmax(),
+			"rbf": NewRBF(28*28+5, 40, 3, 40, 5).UseSoftmax(),
+		},
+		MaxEpochs:    10000,
+		MaxScore:     1,
+		TrainingSize: 100,
+		TestingBatch: 10,
+		TestingCount: 10,
+	},
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"log"
+
+	"github.com/unixpickle/seqtasks"
+)
+
+// A TransferTask runs a transfer learning benchmark,
+// comparing fine-tuned models to models trained from
+// scratch.
+//
+// Since every training run needs a fresh model, Models
+// maps model names to constructors.
+type TransferTask struct {
+	Name      string
+	Benchmark *seqtasks.TransferBenchmark
+	Models    map[string]func() seqtasks.Model
+}
+
+func (t *TransferTask) Run(modelName string) {
+	newModel, ok := t.Models[modelName]
+	if !ok {
+		log.Printf("Model \"%s\" not implemented for task \"%s\"", modelName, t.Name)
+		return
+	}
+	log.Printf("Running task \"%s\" with model \"%s\"", t.Name, modelName)
+	report := t.Benchmark.Run(newModel, func(task, epoch int, score float64) {
+		log.Printf("task %d epoch %d: score=%f", task, epoch, score)
+	})
+	log.Printf("Transfer efficiency (samples to reach score %f):", t.Benchmark.MaxScore)
+	for j, scratch := range report.Scratch {
+		log.Printf("scratch -> %d: samples=%d reached=%v", j, scratch.Samples, scratch.Reached)
+		for i, row := range report.Transfer {
+			if i == j {
+				continue
+			}
+			res := row[j]
+			log.Printf("%d -> %d: samples=%d reached=%v initial=%f speedup=%f", i, j,
+				res.Samples, res.Reached, res.InitialScore, report.Speedup(i, j))
+		}
+	}
+}
+
+var Transfer = &TransferTask{
+	Name: "Transfer",
+	Benchmark: &seqtasks.TransferBenchmark{
+		Tasks: []seqtasks.Task{
+			&seqtasks.RepeatTask{
+				MinString: 2,
+				MaxString: 5,
+				MinGap:    0,
+				MaxGap:    6,
+			},
+			&seqtasks.MatchOpenTask{
+				MinLen:  1,
+				MaxLen:  15,
+				MaxOpen: 6,
+			},
+			&seqtasks.AdditionTask{MaxDigits: 3, Base: 4},
+		},
+		MaxEpochs:    100,
+		MaxScore:     1,
+		TrainingSize: 300,
+		TestingBatch: 10,
+		TestingCount: 30,
+	},
+	Models: map[string]func() seqtasks.Model{
+		"lstm": func() seqtasks.Model {
+			return NewLSTM(5, 100, 1, 100, 4)
+		},
+		"stack": func() seqtasks.Model {
+			return NewStructLSTM(Structs["stack"], 5, 40, 1, 40, 4)
+		},
+		"queue": func() seqtasks.Model {
+			return NewStructLSTM(Structs["queue"], 5, 40, 1, 40, 4)
+		},
+		"multistack": func() seqtasks.Model {
+			return NewStructLSTM(Structs["multistack"], 5, 40, 1, 40, 4)
+		},
+		"multiqueue": func() seqtasks.Model {
+			return NewStructLSTM(Structs["multiqueue"], 5, 40, 1, 40, 4)
+		},
+		"irnn": func() seqtasks.Model {
+			return NewIRNN(5, 40, 3, 40, 4, 1)
+		},
+		"nprnn": func() seqtasks.Model {
+			return NewNPRNN(5, 40, 3, 40, 4)
+		},
+		"ffstruct": func() seqtasks.Model {
+			return NewStructFeedforward(Structs["ffstruct"], 5, 4, 40)
+		},
+		"hebbnet": func() seqtasks.Model {
+			return NewHebbNet(5, 20, 2, 40, 4)
+		},
+		"cwrnn": func() seqtasks.Model {
+			return NewCWRNN(false, 5, 4, []int{1, 2, 4, 8}, []int{20, 20, 20, 20})
+		},
+		"cwrnnfc": func() seqtasks.Model {
+			return NewCWRNN(true, 5, 4, []int{1, 2, 4, 8}, []int{20, 20, 20, 20})
+		},
+		"rbf": func() seqtasks.Model {
+			return NewRBF(5, 40, 3, 40, 4)
+		},
+	},
+}
@@ -0,0 +1,182 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// These are the instructions of an RPNTask program, not
+// counting digit pushes.
+// The input symbol for an instruction is the base plus the
+// instruction's value.
+const (
+	rpnAdd = iota
+	rpnMultiply
+	rpnDup
+	rpnSwap
+	rpnPop
+	rpnInstructionCount
+)
+
+// RPNTask requires the model to run a program for a stack
+// machine, written in reverse Polish notation.
+//
+// Each instruction either pushes a digit, adds or multiplies
+// the top two values, duplicates the top value, swaps the top
+// two values, or pops the top value.
+// All arithmetic is done modulo the base, so every value on
+// the stack is a single digit.
+type RPNTask struct {
+	// Base is the base of the digits on the stack.
+	Base int
+
+	// MinLen is the minimum number of instructions in a
+	// program.
+	MinLen int
+
+	// MaxLen is the maximum number of instructions in a
+	// program.
+	MaxLen int
+
+	// MaxStack is the maximum number of values that the
+	// stack may hold at once.
+	MaxStack int
+
+	// FinalStack determines what the model must output.
+	//
+	// If false, the model must output the top of the stack
+	// after every instruction (or an "empty" symbol if the
+	// stack is empty).
+	//
+	// If true, the program is followed by a delimiter, after
+	// which the model must output the stack's contents from
+	// top to bottom, followed by an end symbol.
+	FinalStack bool
+}
+
+// InputSize returns the number of input symbols, which
+// varies with the base.
+// The symbols are the digits, the non-push instructions,
+// and a delimiter.
+func (r *RPNTask) InputSize() int {
+	return r.Base + rpnInstructionCount + 1
+}
+
+// OutputSize returns the number of output symbols, which
+// varies with the base.
+// The symbols are the digits and an "empty" or end symbol.
+func (r *RPNTask) OutputSize() int {
+	return r.Base + 1
+}
+
+// NewSamples creates a set of samples.
+func (r *RPNTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroIn := make(linalg.Vector, r.InputSize())
+	zeroOut := make(linalg.Vector, r.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		var stack []int
+		programLen := rand.Intn(r.MaxLen-r.MinLen+1) + r.MinLen
+		for j := 0; j < programLen; j++ {
+			symbol := r.randomInstruction(len(stack))
+			stack = r.runInstruction(stack, symbol)
+			inVec := make(linalg.Vector, r.InputSize())
+			inVec[symbol] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			if r.FinalStack {
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			} else {
+				outVec := make(linalg.Vector, r.OutputSize())
+				if len(stack) == 0 {
+					outVec[r.Base] = 1
+				} else {
+					outVec[stack[len(stack)-1]] = 1
+				}
+				sample.Outputs = append(sample.Outputs, outVec)
+			}
+		}
+		if r.FinalStack {
+			inDelimiter := make(linalg.Vector, r.InputSize())
+			inDelimiter[len(inDelimiter)-1] = 1
+			sample.Inputs = append(sample.Inputs, inDelimiter)
+			sample.Outputs = append(sample.Outputs, zeroOut)
+			for j := len(stack) - 1; j >= 0; j-- {
+				outVec := make(linalg.Vector, r.OutputSize())
+				outVec[stack[j]] = 1
+				sample.Inputs = append(sample.Inputs, zeroIn)
+				sample.Outputs = append(sample.Outputs, outVec)
+			}
+			outEnd := make(linalg.Vector, r.OutputSize())
+			outEnd[r.Base] = 1
+			sample.Inputs = append(sample.Inputs, zeroIn)
+			sample.Outputs = append(sample.Outputs, outEnd)
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct (rounded) outputs.
+// If r.FinalStack is set, only outputs after the delimiter
+// are counted.
+func (r *RPNTask) Score(model Model, batchSize, batchCount int) float64 {
+	if !r.FinalStack {
+		return roundedBinaryScore(r, model, batchSize, batchCount)
+	}
+	return roundedBinaryTailScore(r, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		for i, x := range s {
+			if x[len(x)-1] == 1 {
+				return i + 1
+			}
+		}
+		panic("no tail found")
+	})
+}
+
+// randomInstruction returns the input symbol for a random
+// instruction which is valid for a stack of the given size.
+func (r *RPNTask) randomInstruction(stackSize int) int {
+	var options []int
+	if stackSize < r.MaxStack {
+		for i := 0; i < r.Base; i++ {
+			options = append(options, i)
+		}
+		if stackSize >= 1 {
+			options = append(options, r.Base+rpnDup)
+		}
+	}
+	if stackSize >= 1 {
+		options = append(options, r.Base+rpnPop)
+	}
+	if stackSize >= 2 {
+		options = append(options, r.Base+rpnAdd, r.Base+rpnMultiply, r.Base+rpnSwap)
+	}
+	return options[rand.Intn(len(options))]
+}
+
+func (r *RPNTask) runInstruction(stack []int, symbol int) []int {
+	if symbol < r.Base {
+		return append(stack, symbol)
+	}
+	top := len(stack) - 1
+	switch symbol - r.Base {
+	case rpnAdd:
+		stack[top-1] = (stack[top-1] + stack[top]) % r.Base
+		return stack[:top]
+	case rpnMultiply:
+		stack[top-1] = (stack[top-1] * stack[top]) % r.Base
+		return stack[:top]
+	case rpnDup:
+		return append(stack, stack[top])
+	case rpnSwap:
+		stack[top-1], stack[top] = stack[top], stack[top-1]
+		return stack
+	case rpnPop:
+		return stack[:top]
+	}
+	panic("unknown instruction")
+}
@@ -0,0 +1,116 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// RunLengthTask requires the model to compress a string of
+// symbols with run-length encoding.
+//
+// The model is fed a string of symbols, followed by a
+// delimiter.
+// It must then output each run of repeated symbols as a
+// symbol token followed by a count token, and finally an
+// end symbol.
+// For example, "aaabcc" would be encoded as "a3b1c2".
+//
+// Every sequence ends with enough timesteps for the longest
+// possible encoding, so the model must decide how long its
+// answer is.
+// Outputs after the end symbol are ignored during scoring.
+type RunLengthTask struct {
+	// SymbolCount is the number of distinct symbols.
+	// It must be at least 2.
+	SymbolCount int
+
+	// MinLen is the minimum length of an input string.
+	MinLen int
+
+	// MaxLen is the maximum length of an input string.
+	MaxLen int
+
+	// MaxRun is the maximum length of a run of symbols.
+	MaxRun int
+}
+
+// InputSize returns one more than the number of symbols,
+// since there is a delimiter.
+func (r *RunLengthTask) InputSize() int {
+	return r.SymbolCount + 1
+}
+
+// OutputSize returns the number of output tokens.
+// The tokens are the symbols, the counts from 1 to
+// r.MaxRun, and an end symbol.
+func (r *RunLengthTask) OutputSize() int {
+	return r.SymbolCount + r.MaxRun + 1
+}
+
+// NewSamples creates a set of samples.
+func (r *RunLengthTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroIn := make(linalg.Vector, r.InputSize())
+	zeroOut := make(linalg.Vector, r.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		var encoded []int
+		strLen := rand.Intn(r.MaxLen-r.MinLen+1) + r.MinLen
+		symbol := -1
+		for length := 0; length < strLen; {
+			if symbol < 0 {
+				symbol = rand.Intn(r.SymbolCount)
+			} else {
+				symbol = (symbol + rand.Intn(r.SymbolCount-1) + 1) % r.SymbolCount
+			}
+			runLen := rand.Intn(r.MaxRun) + 1
+			if runLen > strLen-length {
+				runLen = strLen - length
+			}
+			for j := 0; j < runLen; j++ {
+				inVec := make(linalg.Vector, r.InputSize())
+				inVec[symbol] = 1
+				sample.Inputs = append(sample.Inputs, inVec)
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			}
+			encoded = append(encoded, symbol, r.SymbolCount+runLen-1)
+			length += runLen
+		}
+		encoded = append(encoded, r.SymbolCount+r.MaxRun)
+
+		inDelimiter := make(linalg.Vector, r.InputSize())
+		inDelimiter[r.SymbolCount] = 1
+		sample.Inputs = append(sample.Inputs, inDelimiter)
+		sample.Outputs = append(sample.Outputs, zeroOut)
+		for j := 0; j < 2*r.MaxLen+1; j++ {
+			sample.Inputs = append(sample.Inputs, zeroIn)
+			if j < len(encoded) {
+				outVec := make(linalg.Vector, r.OutputSize())
+				outVec[encoded[j]] = 1
+				sample.Outputs = append(sample.Outputs, outVec)
+			} else {
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			}
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score decodes the model's encodings up to the end symbol
+// and measures their similarity to the correct encodings,
+// using the edit distance.
+// A score of 1 indicates that every encoding is correct.
+func (r *RunLengthTask) Score(model Model, batchSize, batchCount int) float64 {
+	return editDistanceTailScore(r, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		for i, x := range s {
+			if x[r.SymbolCount] == 1 {
+				return i + 1
+			}
+		}
+		panic("no tail found")
+	}, r.SymbolCount+r.MaxRun)
+}
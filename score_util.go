@@ -1,6 +1,8 @@
 package seqtasks
 
 import (
+	"math"
+
 	"github.com/unixpickle/num-analysis/linalg"
 	"github.com/unixpickle/weakai/rnn/seqtoseq"
 )
@@ -53,3 +55,138 @@ func roundedBinaryTailScore(t Task, m Model, batchSize, batchCount int,
 	}
 	return float64(totalCorrect) / float64(totalOutputs)
 }
+
+// argmaxTailScore is like roundedBinaryTailScore, but it
+// treats each output vector as a classification and counts
+// the fraction of tail timesteps for which the model's
+// largest output matches the expected class.
+func argmaxTailScore(t Task, m Model, batchSize, batchCount int,
+	tailFunc func(seq []linalg.Vector) int) float64 {
+	var totalOutputs int
+	var totalCorrect int
+	for i := 0; i < batchCount; i++ {
+		batch := t.NewSamples(batchSize)
+		var inputs [][]linalg.Vector
+		var expected [][]linalg.Vector
+		for i := 0; i < batch.Len(); i++ {
+			sample := batch.GetSample(i).(seqtoseq.Sample)
+			inputs = append(inputs, sample.Inputs)
+			expected = append(expected, sample.Outputs)
+		}
+		actual := m.Run(inputs)
+		for lane, expSeq := range expected {
+			tailIdx := tailFunc(inputs[lane])
+			actSeq := actual[lane][tailIdx:]
+			for t, expVec := range expSeq[tailIdx:] {
+				if maxIdx(actSeq[t]) == maxIdx(expVec) {
+					totalCorrect++
+				}
+				totalOutputs++
+			}
+		}
+	}
+	return float64(totalCorrect) / float64(totalOutputs)
+}
+
+// nrmseScore returns the root-mean-square error of the
+// model's outputs, normalized by the standard deviation
+// of the expected outputs.
+// All timesteps and output components are counted.
+func nrmseScore(t Task, m Model, batchSize, batchCount int) float64 {
+	var expectedVals, actualVals []float64
+	for i := 0; i < batchCount; i++ {
+		batch := t.NewSamples(batchSize)
+		var inputs [][]linalg.Vector
+		for i := 0; i < batch.Len(); i++ {
+			sample := batch.GetSample(i).(seqtoseq.Sample)
+			inputs = append(inputs, sample.Inputs)
+			for _, vec := range sample.Outputs {
+				expectedVals = append(expectedVals, vec...)
+			}
+		}
+		for _, seq := range m.Run(inputs) {
+			for _, vec := range seq {
+				actualVals = append(actualVals, vec...)
+			}
+		}
+	}
+	var mean float64
+	for _, x := range expectedVals {
+		mean += x
+	}
+	mean /= float64(len(expectedVals))
+	var sqError, variance float64
+	for i, x := range expectedVals {
+		sqError += math.Pow(x-actualVals[i], 2)
+		variance += math.Pow(x-mean, 2)
+	}
+	return math.Sqrt(sqError / variance)
+}
+
+// mseScore returns the mean squared error of the model's
+// outputs.
+// All timesteps and output components are counted.
+func mseScore(t Task, m Model, batchSize, batchCount int) float64 {
+	var totalError float64
+	var totalOutputs int
+	for i := 0; i < batchCount; i++ {
+		batch := t.NewSamples(batchSize)
+		var inputs [][]linalg.Vector
+		var expected [][]linalg.Vector
+		for i := 0; i < batch.Len(); i++ {
+			sample := batch.GetSample(i).(seqtoseq.Sample)
+			inputs = append(inputs, sample.Inputs)
+			expected = append(expected, sample.Outputs)
+		}
+		actual := m.Run(inputs)
+		for lane, expSeq := range expected {
+			for t, expVec := range expSeq {
+				for j, x := range expVec {
+					totalError += math.Pow(x-actual[lane][t][j], 2)
+					totalOutputs++
+				}
+			}
+		}
+	}
+	return totalError / float64(totalOutputs)
+}
+
+// editDistanceTailScore decodes the tail of each expected
+// and actual output sequence into a token string, stopping
+// at the end-of-sequence token.
+// It returns the average similarity between the strings,
+// where the similarity is 1 minus the edit distance
+// divided by the length of the longer string.
+func editDistanceTailScore(t Task, m Model, batchSize, batchCount int,
+	tailFunc func(seq []linalg.Vector) int, eos int) float64 {
+	var totalSimilarity float64
+	var totalSequences int
+	for i := 0; i < batchCount; i++ {
+		batch := t.NewSamples(batchSize)
+		var inputs [][]linalg.Vector
+		var expected [][]linalg.Vector
+		for i := 0; i < batch.Len(); i++ {
+			sample := batch.GetSample(i).(seqtoseq.Sample)
+			inputs = append(inputs, sample.Inputs)
+			expected = append(expected, sample.Outputs)
+		}
+		actual := m.Run(inputs)
+		for lane, expSeq := range expected {
+			tailIdx := tailFunc(inputs[lane])
+			expTokens := DecodeTokens(expSeq[tailIdx:], eos)
+			actTokens := DecodeTokens(actual[lane][tailIdx:], eos)
+			maxLen := len(expTokens)
+			if len(actTokens) > maxLen {
+				maxLen = len(actTokens)
+			}
+			if maxLen > 0 {
+				dist := EditDistance(expTokens, actTokens)
+				totalSimilarity += 1 - float64(dist)/float64(maxLen)
+			} else {
+				totalSimilarity++
+			}
+			totalSequences++
+		}
+	}
+	return totalSimilarity / float64(totalSequences)
+}
@@ -0,0 +1,75 @@
+package seqtasks
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// SineClassificationTask requires the model to determine
+// the frequency of a noisy sine wave.
+//
+// The wave starts at a random phase and is given to the
+// model one timestep at a time, followed by an end marker.
+// At the end marker, the model must classify the wave's
+// frequency.
+type SineClassificationTask struct {
+	// Frequencies lists the possible frequencies, measured
+	// in cycles per timestep.
+	Frequencies []float64
+
+	// Noise is the standard deviation of the Gaussian noise
+	// added to each timestep of the wave.
+	Noise float64
+
+	// SeqLen is the length of the wave.
+	SeqLen int
+}
+
+// InputSize returns 2, since there is a wave input and an
+// end marker input.
+func (s *SineClassificationTask) InputSize() int {
+	return 2
+}
+
+// OutputSize returns the number of frequencies, since the
+// model must choose between them.
+func (s *SineClassificationTask) OutputSize() int {
+	return len(s.Frequencies)
+}
+
+// NewSamples creates a set of samples.
+func (s *SineClassificationTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroOut := make(linalg.Vector, s.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		freqIdx := rand.Intn(len(s.Frequencies))
+		freq := s.Frequencies[freqIdx]
+		phase := rand.Float64() * 2 * math.Pi
+		for t := 0; t < s.SeqLen; t++ {
+			wave := (1+math.Sin(2*math.Pi*freq*float64(t)+phase))/2 +
+				rand.NormFloat64()*s.Noise
+			sample.Inputs = append(sample.Inputs, []float64{wave, 0})
+			sample.Outputs = append(sample.Outputs, zeroOut)
+		}
+		sample.Inputs = append(sample.Inputs, []float64{0, 1})
+		outVec := make(linalg.Vector, s.OutputSize())
+		outVec[freqIdx] = 1
+		sample.Outputs = append(sample.Outputs, outVec)
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correctly classified
+// waves, where the model's largest output at the end
+// marker is taken to be its classification.
+func (s *SineClassificationTask) Score(model Model, batchSize, batchCount int) float64 {
+	return argmaxTailScore(s, model, batchSize, batchCount, func(seq []linalg.Vector) int {
+		return len(seq) - 1
+	})
+}
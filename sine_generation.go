@@ -0,0 +1,77 @@
+package seqtasks
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// SineGenerationTask requires the model to generate a
+// sine wave whose frequency is specified by the input.
+//
+// The input at every timestep specifies the frequency.
+// The output at timestep t must be (1+sin(2*pi*f*t))/2,
+// where f is the frequency in cycles per timestep.
+type SineGenerationTask struct {
+	// Frequencies lists the possible frequencies, measured
+	// in cycles per timestep.
+	Frequencies []float64
+
+	// OneHot determines how the frequency is given to the
+	// model.
+	// If true, the input is a one-hot vector indicating
+	// the index of the frequency in Frequencies.
+	// If false, the input is the frequency itself, scaled
+	// so that a frequency of 0.5 (the Nyquist frequency)
+	// is 1.
+	OneHot bool
+
+	// SeqLen is the length of test sequences.
+	SeqLen int
+}
+
+// InputSize returns the number of frequencies if s.OneHot
+// is set, or 1 otherwise.
+func (s *SineGenerationTask) InputSize() int {
+	if s.OneHot {
+		return len(s.Frequencies)
+	}
+	return 1
+}
+
+// OutputSize returns 1, since the wave is scalar.
+func (s *SineGenerationTask) OutputSize() int {
+	return 1
+}
+
+// NewSamples creates a set of samples.
+func (s *SineGenerationTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		freqIdx := rand.Intn(len(s.Frequencies))
+		freq := s.Frequencies[freqIdx]
+		inVec := make(linalg.Vector, s.InputSize())
+		if s.OneHot {
+			inVec[freqIdx] = 1
+		} else {
+			inVec[0] = freq * 2
+		}
+		for t := 0; t < s.SeqLen; t++ {
+			wave := (1 + math.Sin(2*math.Pi*freq*float64(t))) / 2
+			sample.Inputs = append(sample.Inputs, inVec)
+			sample.Outputs = append(sample.Outputs, []float64{wave})
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score returns 1 minus the mean squared error of the
+// model's outputs.
+func (s *SineGenerationTask) Score(model Model, batchSize, batchCount int) float64 {
+	return 1 - mseScore(s, model, batchSize, batchCount)
+}
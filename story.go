@@ -0,0 +1,267 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// A StoryQuestion is a type of question asked at the end
+// of a story in a StoryTask.
+type StoryQuestion int
+
+const (
+	// WhereQuestion asks where a person or object is, as in
+	// "where is John ?".
+	// The answer is a location.
+	WhereQuestion StoryQuestion = iota
+
+	// CountQuestion asks how many objects a person is
+	// carrying, as in "how-many John ?".
+	// The answer is a number.
+	CountQuestion
+
+	// YesNoQuestion asks whether a person is in a given
+	// location, as in "is John in kitchen ?".
+	// The answer is yes or no.
+	YesNoQuestion
+)
+
+// These are the words in a StoryTask vocabulary which are
+// not names.
+// The input symbol for a word is the number of names plus
+// the word's value.
+const (
+	storyWent = iota
+	storyGot
+	storyDropped
+	storyPeriod
+	storyWhere
+	storyHowMany
+	storyIs
+	storyIn
+	storyQuestionMark
+	storyWordCount
+)
+
+// StoryTask is a question answering task in the style of
+// the bAbI tasks.
+//
+// Each sample is a short story in which people move between
+// locations, pick up objects, and drop them, such as:
+//
+//	John went kitchen .
+//	John got apple .
+//	Mary went garden .
+//	John went garden .
+//	John dropped apple .
+//
+// The story is followed by a question, such as
+// "where is apple ?".
+// The model is fed one word per timestep, and it must answer
+// the question at the final "?".
+//
+// Names are drawn from three word lists of configurable
+// sizes: people, locations, and objects.
+type StoryTask struct {
+	PersonCount   int
+	LocationCount int
+	ObjectCount   int
+
+	// MinStatements is the minimum number of sentences in a
+	// story.
+	// It must be at least 1.
+	MinStatements int
+
+	// MaxStatements is the maximum number of sentences in a
+	// story.
+	MaxStatements int
+
+	// QuestionTypes lists the types of questions to ask.
+	// If this is empty, every type of question is used.
+	QuestionTypes []StoryQuestion
+}
+
+// InputSize returns the number of words in the vocabulary,
+// which varies with the number of names.
+func (s *StoryTask) InputSize() int {
+	return s.nameCount() + storyWordCount
+}
+
+// OutputSize returns the number of possible answers.
+// The answers are the locations, the numbers from 0 to
+// s.ObjectCount, yes, and no.
+func (s *StoryTask) OutputSize() int {
+	return s.LocationCount + s.ObjectCount + 3
+}
+
+// NewSamples creates a set of samples.
+func (s *StoryTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroOut := make(linalg.Vector, s.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		words, answer := s.randomStory()
+		for _, word := range words {
+			inVec := make(linalg.Vector, s.InputSize())
+			inVec[word] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			sample.Outputs = append(sample.Outputs, zeroOut)
+		}
+		outVec := make(linalg.Vector, s.OutputSize())
+		outVec[answer] = 1
+		sample.Outputs[len(sample.Outputs)-1] = outVec
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correctly answered
+// questions, where the model's largest output at the "?"
+// is taken to be its answer.
+func (s *StoryTask) Score(model Model, batchSize, batchCount int) float64 {
+	return argmaxTailScore(s, model, batchSize, batchCount, func(seq []linalg.Vector) int {
+		return len(seq) - 1
+	})
+}
+
+// randomStory generates the words for a story and a
+// question, and returns them along with the answer symbol.
+func (s *StoryTask) randomStory() (words []int, answer int) {
+	personLocs := make([]int, s.PersonCount)
+	objectLocs := make([]int, s.ObjectCount)
+	holders := make([]int, s.ObjectCount)
+	for i := range personLocs {
+		personLocs[i] = -1
+	}
+	for i := range objectLocs {
+		objectLocs[i] = -1
+		holders[i] = -1
+	}
+
+	statementCount := rand.Intn(s.MaxStatements-s.MinStatements+1) + s.MinStatements
+	for i := 0; i < statementCount; i++ {
+		// People may pick up objects that are in the same
+		// location or that haven't been mentioned yet.
+		var getters, gettable, holding []int
+		for obj, holder := range holders {
+			if holder >= 0 {
+				holding = append(holding, obj)
+				continue
+			}
+			for person, loc := range personLocs {
+				if loc >= 0 && (objectLocs[obj] < 0 || objectLocs[obj] == loc) {
+					getters = append(getters, person)
+					gettable = append(gettable, obj)
+				}
+			}
+		}
+		actions := []int{storyWent}
+		if len(getters) > 0 {
+			actions = append(actions, storyGot)
+		}
+		if len(holding) > 0 {
+			actions = append(actions, storyDropped)
+		}
+		switch actions[rand.Intn(len(actions))] {
+		case storyWent:
+			person := rand.Intn(s.PersonCount)
+			loc := rand.Intn(s.LocationCount)
+			personLocs[person] = loc
+			for obj, holder := range holders {
+				if holder == person {
+					objectLocs[obj] = loc
+				}
+			}
+			words = append(words, s.personWord(person), s.word(storyWent), s.locationWord(loc))
+		case storyGot:
+			idx := rand.Intn(len(getters))
+			person, obj := getters[idx], gettable[idx]
+			holders[obj] = person
+			objectLocs[obj] = personLocs[person]
+			words = append(words, s.personWord(person), s.word(storyGot), s.objectWord(obj))
+		case storyDropped:
+			obj := holding[rand.Intn(len(holding))]
+			words = append(words, s.personWord(holders[obj]), s.word(storyDropped),
+				s.objectWord(obj))
+			holders[obj] = -1
+		}
+		words = append(words, s.word(storyPeriod))
+	}
+
+	questionTypes := s.QuestionTypes
+	if len(questionTypes) == 0 {
+		questionTypes = []StoryQuestion{WhereQuestion, CountQuestion, YesNoQuestion}
+	}
+	var located []int
+	for person, loc := range personLocs {
+		if loc >= 0 {
+			located = append(located, person)
+		}
+	}
+	switch questionTypes[rand.Intn(len(questionTypes))] {
+	case WhereQuestion:
+		// Ask about any person or object with a known location.
+		var subjects, locs []int
+		for _, person := range located {
+			subjects = append(subjects, s.personWord(person))
+			locs = append(locs, personLocs[person])
+		}
+		for obj, loc := range objectLocs {
+			if loc >= 0 {
+				subjects = append(subjects, s.objectWord(obj))
+				locs = append(locs, loc)
+			}
+		}
+		idx := rand.Intn(len(subjects))
+		words = append(words, s.word(storyWhere), s.word(storyIs), subjects[idx])
+		answer = locs[idx]
+	case CountQuestion:
+		person := rand.Intn(s.PersonCount)
+		var count int
+		for _, holder := range holders {
+			if holder == person {
+				count++
+			}
+		}
+		words = append(words, s.word(storyHowMany), s.personWord(person))
+		answer = s.LocationCount + count
+	case YesNoQuestion:
+		person := located[rand.Intn(len(located))]
+		loc := personLocs[person]
+		if rand.Intn(2) == 0 {
+			loc = rand.Intn(s.LocationCount)
+		}
+		words = append(words, s.word(storyIs), s.personWord(person), s.word(storyIn),
+			s.locationWord(loc))
+		if loc == personLocs[person] {
+			answer = s.LocationCount + s.ObjectCount + 1
+		} else {
+			answer = s.LocationCount + s.ObjectCount + 2
+		}
+	}
+	words = append(words, s.word(storyQuestionMark))
+	return
+}
+
+func (s *StoryTask) nameCount() int {
+	return s.PersonCount + s.LocationCount + s.ObjectCount
+}
+
+func (s *StoryTask) personWord(person int) int {
+	return person
+}
+
+func (s *StoryTask) locationWord(loc int) int {
+	return s.PersonCount + loc
+}
+
+func (s *StoryTask) objectWord(obj int) int {
+	return s.PersonCount + s.LocationCount + obj
+}
+
+func (s *StoryTask) word(w int) int {
+	return s.nameCount() + w
+}
@@ -0,0 +1,127 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// These are the actions in a TMazeEnvironment.
+// The output index for an action is the action's value.
+const (
+	tMazeForward = iota
+	tMazeUp
+	tMazeDown
+	tMazeActionCount
+)
+
+// TMazeEnvironment is the T-maze memory task from
+// "Reinforcement Learning with Long Short-Term Memory" by
+// Bakker.
+//
+// The model starts at the bottom of a corridor which ends in
+// a T-junction.
+// At the start, it sees a cue indicating whether the goal is
+// up or down at the junction.
+// It must then move forward through the corridor, where the
+// cue is no longer visible, and turn the right way at the
+// junction.
+//
+// Each input is a one-hot vector giving one of four
+// observations: the "up" cue, the "down" cue, the corridor,
+// or the junction.
+// The model chooses the action with the largest output,
+// where the actions are forward, up, and down.
+// Turning in the corridor or moving forward at the junction
+// has no effect.
+//
+// The episode ends with a reward of 1 if the model turns the
+// right way at the junction, or 0 if it turns the wrong way
+// or takes too long.
+type TMazeEnvironment struct {
+	// Length is the number of forward moves it takes to get
+	// from the start to the junction.
+	Length int
+
+	// MaxSteps is the maximum number of steps in an episode.
+	// If this is 0, it is twice the length plus one.
+	MaxSteps int
+
+	goalUp bool
+	pos    int
+	step   int
+}
+
+// InputSize returns 4, since there are four observations.
+func (t *TMazeEnvironment) InputSize() int {
+	return 4
+}
+
+// OutputSize returns 3, since there are three actions.
+func (t *TMazeEnvironment) OutputSize() int {
+	return tMazeActionCount
+}
+
+// Reset puts the model at the start and picks a random
+// goal.
+func (t *TMazeEnvironment) Reset() {
+	t.goalUp = rand.Intn(2) == 0
+	t.pos = 0
+	t.step = 0
+}
+
+// Observe returns the observation for the current
+// position.
+func (t *TMazeEnvironment) Observe() linalg.Vector {
+	res := make(linalg.Vector, t.InputSize())
+	switch {
+	case t.pos == t.Length:
+		res[3] = 1
+	case t.pos > 0:
+		res[2] = 1
+	case t.goalUp:
+		res[0] = 1
+	default:
+		res[1] = 1
+	}
+	return res
+}
+
+// Target returns the forward action in the corridor and the
+// correct turn at the junction.
+func (t *TMazeEnvironment) Target() linalg.Vector {
+	res := make(linalg.Vector, t.OutputSize())
+	switch {
+	case t.pos < t.Length:
+		res[tMazeForward] = 1
+	case t.goalUp:
+		res[tMazeUp] = 1
+	default:
+		res[tMazeDown] = 1
+	}
+	return res
+}
+
+// Step takes the action with the largest output.
+func (t *TMazeEnvironment) Step(output linalg.Vector) (reward float64, done bool) {
+	t.step++
+	action := maxIdx(output)
+	if t.pos < t.Length {
+		if action == tMazeForward {
+			t.pos++
+		}
+	} else if action != tMazeForward {
+		if (action == tMazeUp) == t.goalUp {
+			reward = 1
+		}
+		return reward, true
+	}
+	return 0, t.step >= t.maxSteps()
+}
+
+func (t *TMazeEnvironment) maxSteps() int {
+	if t.MaxSteps == 0 {
+		return 2*t.Length + 1
+	}
+	return t.MaxSteps
+}
@@ -0,0 +1,172 @@
+package seqtasks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// TextTask is a character-level language modeling task.
+// At each timestep, the model is given a character and
+// must predict the next character.
+//
+// Characters are encoded as one-hot vectors, and the
+// model's outputs are treated as a probability
+// distribution over the next character.
+type TextTask struct {
+	// Vocab is the list of characters, indexed by symbol.
+	Vocab []rune
+
+	// Training is the training text, encoded as indices
+	// into Vocab.
+	Training []int
+
+	// Testing is the held-out text, encoded as indices
+	// into Vocab.
+	Testing []int
+
+	// SeqLen is the number of characters in each sample.
+	SeqLen int
+}
+
+// NewTextTask creates a TextTask for the given text.
+// The vocabulary consists of every character in the text.
+// The last testFrac of the text is held out for testing.
+//
+// An error is returned if either the training or testing
+// text has fewer than seqLen+1 characters, since samples
+// could not be created.
+func NewTextTask(text string, seqLen int, testFrac float64) (*TextTask, error) {
+	runes := []rune(text)
+	symbols := map[rune]int{}
+	res := &TextTask{SeqLen: seqLen}
+	for _, r := range runes {
+		if _, ok := symbols[r]; !ok {
+			symbols[r] = 0
+			res.Vocab = append(res.Vocab, r)
+		}
+	}
+	sort.Slice(res.Vocab, func(i, j int) bool {
+		return res.Vocab[i] < res.Vocab[j]
+	})
+	for i, r := range res.Vocab {
+		symbols[r] = i
+	}
+	encoded := make([]int, len(runes))
+	for i, r := range runes {
+		encoded[i] = symbols[r]
+	}
+	split := len(encoded) - int(float64(len(encoded))*testFrac)
+	res.Training = encoded[:split]
+	res.Testing = encoded[split:]
+	if len(res.Training) < seqLen+1 || len(res.Testing) < seqLen+1 {
+		return nil, fmt.Errorf("new text task: need %d characters per split but got "+
+			"%d training and %d testing", seqLen+1, len(res.Training), len(res.Testing))
+	}
+	return res, nil
+}
+
+// LoadTextTask creates a TextTask for a text file, or for
+// the concatenated contents of every file in a directory.
+// See NewTextTask for details.
+func LoadTextTask(path string, seqLen int, testFrac float64) (*TextTask, error) {
+	var text []byte
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		contents, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		text = append(text, contents...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewTextTask(string(text), seqLen, testFrac)
+}
+
+// InputSize returns the number of characters in the
+// vocabulary.
+func (t *TextTask) InputSize() int {
+	return len(t.Vocab)
+}
+
+// OutputSize returns the number of characters in the
+// vocabulary.
+func (t *TextTask) OutputSize() int {
+	return len(t.Vocab)
+}
+
+// NewSamples creates a set of samples from random windows
+// of the training text.
+func (t *TextTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		res = append(res, t.randomWindow(t.Training))
+	}
+	return res
+}
+
+// Score returns the negative of the model's bits per
+// character on the testing text, so that higher scores
+// are better.
+func (t *TextTask) Score(model Model, batchSize, batchCount int) float64 {
+	return -t.BitsPerCharacter(model, batchSize, batchCount)
+}
+
+// BitsPerCharacter measures the average negative log
+// probability (in base 2) which the model assigns to each
+// character in random windows of the testing text.
+// The model's outputs are normalized to sum to 1.
+func (t *TextTask) BitsPerCharacter(model Model, batchSize, batchCount int) float64 {
+	var totalBits float64
+	var totalChars int
+	for i := 0; i < batchCount; i++ {
+		var inputs [][]linalg.Vector
+		var expected [][]linalg.Vector
+		for j := 0; j < batchSize; j++ {
+			sample := t.randomWindow(t.Testing)
+			inputs = append(inputs, sample.Inputs)
+			expected = append(expected, sample.Outputs)
+		}
+		actual := model.Run(inputs)
+		for lane, expSeq := range expected {
+			for step, expVec := range expSeq {
+				actVec := actual[lane][step]
+				var sum float64
+				for _, x := range actVec {
+					sum += x
+				}
+				prob := math.Max(actVec[maxIdx(expVec)]/sum, 1e-10)
+				totalBits -= math.Log2(prob)
+				totalChars++
+			}
+		}
+	}
+	return totalBits / float64(totalChars)
+}
+
+func (t *TextTask) randomWindow(text []int) seqtoseq.Sample {
+	var sample seqtoseq.Sample
+	start := rand.Intn(len(text) - t.SeqLen)
+	for i := start; i < start+t.SeqLen; i++ {
+		inVec := make(linalg.Vector, len(t.Vocab))
+		inVec[text[i]] = 1
+		outVec := make(linalg.Vector, len(t.Vocab))
+		outVec[text[i+1]] = 1
+		sample.Inputs = append(sample.Inputs, inVec)
+		sample.Outputs = append(sample.Outputs, outVec)
+	}
+	return sample
+}
@@ -0,0 +1,108 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// ThresholdTask feeds the model a stream of symbols and
+// requires it to output whether a target symbol has
+// occurred more than a certain number of times.
+//
+// The target symbol is the first symbol in the alphabet.
+// All the other symbols are distractors.
+type ThresholdTask struct {
+	// SymbolCount is the number of symbols in the
+	// alphabet, including the target symbol.
+	SymbolCount int
+
+	// Threshold is the number of times the target symbol
+	// may occur before the model must output 1.
+	Threshold int
+
+	// TargetProb is the probability that any given symbol
+	// in the stream is the target symbol.
+	TargetProb float64
+
+	// MinLen is the minimum length of the stream.
+	MinLen int
+
+	// MaxLen is the maximum length of the stream.
+	MaxLen int
+
+	// Continuous determines when the model must output
+	// its answer.
+	//
+	// If false, the stream is followed by an end marker,
+	// and the model must only answer at the end marker.
+	//
+	// If true, the model must answer (for the stream so
+	// far) at every timestep.
+	Continuous bool
+}
+
+// InputSize returns the number of symbols plus one, since
+// there is an extra input for the end marker.
+func (t *ThresholdTask) InputSize() int {
+	return t.SymbolCount + 1
+}
+
+// OutputSize returns 1, since the model only needs to
+// output whether or not the threshold was exceeded.
+func (t *ThresholdTask) OutputSize() int {
+	return 1
+}
+
+// NewSamples creates a set of samples.
+func (t *ThresholdTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		var count int
+		streamLen := rand.Intn(t.MaxLen-t.MinLen+1) + t.MinLen
+		for j := 0; j < streamLen; j++ {
+			symbol := 0
+			if rand.Float64() >= t.TargetProb {
+				symbol = rand.Intn(t.SymbolCount-1) + 1
+			} else {
+				count++
+			}
+			inVec := make(linalg.Vector, t.InputSize())
+			inVec[symbol] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			if t.Continuous && count > t.Threshold {
+				sample.Outputs = append(sample.Outputs, []float64{1})
+			} else {
+				sample.Outputs = append(sample.Outputs, []float64{0})
+			}
+		}
+		if !t.Continuous {
+			inVec := make(linalg.Vector, t.InputSize())
+			inVec[t.SymbolCount] = 1
+			sample.Inputs = append(sample.Inputs, inVec)
+			if count > t.Threshold {
+				sample.Outputs = append(sample.Outputs, []float64{1})
+			} else {
+				sample.Outputs = append(sample.Outputs, []float64{0})
+			}
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score computes the fraction of correct (rounded)
+// outputs.
+// If t.Continuous is false, only the end marker's output
+// is counted.
+func (t *ThresholdTask) Score(model Model, batchSize, batchCount int) float64 {
+	return roundedBinaryTailScore(t, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		if t.Continuous {
+			return 0
+		}
+		return len(s) - 1
+	})
+}
@@ -0,0 +1,64 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+)
+
+// TimeWarpTask wraps a Task and randomly stretches its
+// sequences by repeating timesteps.
+// This tests whether a model is invariant to the speed at
+// which a sequence is presented.
+//
+// The expected outputs of repeated timesteps are repeated
+// along with the inputs.
+type TimeWarpTask struct {
+	Task Task
+
+	// MaxRepeat is the maximum number of times a timestep
+	// may appear in a stretched sequence.
+	// Each timestep appears a uniformly random number of
+	// times between 1 and MaxRepeat.
+	MaxRepeat int
+}
+
+// InputSize returns the input size of the wrapped task.
+func (t *TimeWarpTask) InputSize() int {
+	return t.Task.InputSize()
+}
+
+// OutputSize returns the output size of the wrapped task.
+func (t *TimeWarpTask) OutputSize() int {
+	return t.Task.OutputSize()
+}
+
+// NewSamples creates samples with the wrapped task and
+// stretches them.
+func (t *TimeWarpTask) NewSamples(n int) sgd.SampleSet {
+	return retimeSamples(t, t.Task.NewSamples(n), t.InputSize(), t.OutputSize())
+}
+
+// Score scores the model on the wrapped task, stretching
+// every sequence the model sees.
+// For each repeated timestep, only the output at the last
+// repetition is scored.
+func (t *TimeWarpTask) Score(m Model, batchSize, batchCount int) float64 {
+	return t.Task.Score(&retimedModel{Model: m, Retimer: t}, batchSize, batchCount)
+}
+
+func (t *TimeWarpTask) sources(seqLen int) []int {
+	var res []int
+	for i := 0; i < seqLen; i++ {
+		repeat := rand.Intn(t.MaxRepeat) + 1
+		for j := 0; j < repeat; j++ {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func (t *TimeWarpTask) insertedInput(inSize int) linalg.Vector {
+	panic("time warping never inserts timesteps")
+}
@@ -0,0 +1,191 @@
+package seqtasks
+
+// TransferBenchmark measures how much pretraining on one
+// task speeds up learning another task.
+//
+// For every ordered pair of distinct tasks, a fresh model
+// is trained on the source task and then fine-tuned on the
+// target task.
+// The number of epochs needed to reach MaxScore on the
+// target is compared to the number needed by a fresh model
+// trained from scratch.
+//
+// Tasks with smaller input or output sizes are padded with
+// zeroes, so that every task can be run by a model with the
+// benchmark's InputSize and OutputSize.
+type TransferBenchmark struct {
+	Tasks []Task
+
+	// MaxEpochs is the maximum number of epochs to train
+	// on each task.
+	MaxEpochs int
+
+	// MaxScore is the score threshold that training aims
+	// to reach.
+	MaxScore float64
+
+	// TrainingSize is the number of samples per epoch.
+	TrainingSize int
+
+	// TestingBatch and TestingCount are the batch size and
+	// batch count passed to the tasks' Score methods.
+	TestingBatch int
+	TestingCount int
+}
+
+// TransferResult describes a single training run on a
+// target task.
+type TransferResult struct {
+	// PretrainEpochs and PretrainScore are the number of
+	// epochs spent on the source task and the final score
+	// on the source task.
+	// They are 0 for runs from scratch.
+	PretrainEpochs int
+	PretrainScore  float64
+
+	// InitialScore is the score on the target task before
+	// any training on it.
+	InitialScore float64
+
+	// Epochs and Samples are the amount of training on the
+	// target task it took to reach the threshold, or the
+	// total amount of training if it was never reached.
+	Epochs  int
+	Samples int
+
+	// Reached indicates whether the threshold was reached.
+	Reached bool
+
+	// FinalScore is the score on the target task at the end
+	// of training.
+	FinalScore float64
+}
+
+// A TransferReport summarizes a TransferBenchmark run.
+type TransferReport struct {
+	// Scratch contains one result per task, obtained by
+	// training a fresh model.
+	Scratch []TransferResult
+
+	// Transfer contains results for fine-tuning, where entry
+	// (i, j) is for pretraining on task i and fine-tuning on
+	// task j.
+	// Entries with i == j are unused.
+	Transfer [][]TransferResult
+}
+
+// Speedup returns the ratio between the number of samples
+// needed to reach the threshold on the target task from
+// scratch and the number needed after pretraining on the
+// source task.
+// Values above 1 indicate positive transfer.
+//
+// If either run failed to reach the threshold, the maximum
+// number of samples is used in its place, so the result is
+// a bound rather than an exact ratio.
+func (t *TransferReport) Speedup(source, target int) float64 {
+	return float64(t.Scratch[target].Samples) / float64(t.Transfer[source][target].Samples)
+}
+
+// InputSize returns the largest input size of any task.
+func (t *TransferBenchmark) InputSize() int {
+	var res int
+	for _, task := range t.Tasks {
+		if task.InputSize() > res {
+			res = task.InputSize()
+		}
+	}
+	return res
+}
+
+// OutputSize returns the largest output size of any task.
+func (t *TransferBenchmark) OutputSize() int {
+	var res int
+	for _, task := range t.Tasks {
+		if task.OutputSize() > res {
+			res = task.OutputSize()
+		}
+	}
+	return res
+}
+
+// Run runs the benchmark.
+//
+// The newModel function is called to create a fresh model
+// for every training run.
+// Models must have the input and output sizes given by
+// t.InputSize and t.OutputSize.
+//
+// If logFunc is non-nil, it is called after every epoch
+// with the index of the task being trained, the epoch
+// number, and the score on that task.
+func (t *TransferBenchmark) Run(newModel func() Model,
+	logFunc func(task, epoch int, score float64)) *TransferReport {
+	res := &TransferReport{
+		Scratch:  make([]TransferResult, len(t.Tasks)),
+		Transfer: make([][]TransferResult, len(t.Tasks)),
+	}
+	for i := range t.Tasks {
+		res.Scratch[i] = t.RunPair(newModel(), -1, i, logFunc)
+	}
+	for i := range t.Tasks {
+		res.Transfer[i] = make([]TransferResult, len(t.Tasks))
+		for j := range t.Tasks {
+			if i != j {
+				res.Transfer[i][j] = t.RunPair(newModel(), i, j, logFunc)
+			}
+		}
+	}
+	return res
+}
+
+// RunPair trains the model on the source task and then
+// fine-tunes it on the target task.
+// If source is -1, the model is trained on the target task
+// from scratch.
+//
+// The logFunc argument is treated as in Run.
+func (t *TransferBenchmark) RunPair(m Model, source, target int,
+	logFunc func(task, epoch int, score float64)) TransferResult {
+	var res TransferResult
+	if source >= 0 {
+		res.PretrainEpochs, res.PretrainScore, _ = t.train(m, source, logFunc)
+	}
+	res.InitialScore = t.score(m, target)
+	var reached bool
+	res.Epochs, res.FinalScore, reached = t.train(m, target, logFunc)
+	res.Samples = res.Epochs * t.TrainingSize
+	res.Reached = reached
+	return res
+}
+
+// train trains the model on a task until it reaches
+// t.MaxScore or runs out of epochs.
+func (t *TransferBenchmark) train(m Model, taskIdx int,
+	logFunc func(task, epoch int, score float64)) (epochs int, score float64, reached bool) {
+	task := t.paddedTask(taskIdx)
+	for epochs < t.MaxEpochs {
+		m.Train(task.NewSamples(t.TrainingSize))
+		epochs++
+		score = task.Score(m, t.TestingBatch, t.TestingCount)
+		if logFunc != nil {
+			logFunc(taskIdx, epochs-1, score)
+		}
+		if score >= t.MaxScore {
+			return epochs, score, true
+		}
+	}
+	return epochs, score, false
+}
+
+func (t *TransferBenchmark) score(m Model, taskIdx int) float64 {
+	return t.paddedTask(taskIdx).Score(m, t.TestingBatch, t.TestingCount)
+}
+
+func (t *TransferBenchmark) paddedTask(taskIdx int) *paddedTask {
+	return &paddedTask{
+		Task:    t.Tasks[taskIdx],
+		Inputs:  t.InputSize(),
+		Outputs: t.OutputSize(),
+	}
+}
@@ -0,0 +1,113 @@
+package seqtasks
+
+import (
+	"math/rand"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/sgd"
+	"github.com/unixpickle/weakai/rnn/seqtoseq"
+)
+
+// VariableAdditionTask is like AdditionTask, except that
+// the sum is not padded with a carry digit, so the model
+// must decide how long its answer is.
+//
+// After both operands, the model must output the digits of
+// the sum (least significant digit first), followed by an
+// end symbol.
+// Every sequence ends with enough timesteps for the longest
+// possible answer, so the length of the answer cannot be
+// inferred from the length of the sequence.
+// Outputs after the end symbol are ignored during scoring.
+type VariableAdditionTask struct {
+	// MaxDigits is the maximum number of digits in an
+	// operand.
+	MaxDigits int
+
+	// Base is the base of the numbers.
+	Base int
+}
+
+// InputSize returns the number of input symbols, which
+// varies with the base.
+// The symbols are the digits and a delimiter.
+func (v *VariableAdditionTask) InputSize() int {
+	return v.Base + 1
+}
+
+// OutputSize returns the number of output symbols, which
+// varies with the base.
+// The symbols are the digits and an end symbol.
+func (v *VariableAdditionTask) OutputSize() int {
+	return v.Base + 1
+}
+
+// NewSamples creates a set of samples.
+func (v *VariableAdditionTask) NewSamples(n int) sgd.SampleSet {
+	var res sgd.SliceSampleSet
+	zeroIn := make(linalg.Vector, v.InputSize())
+	zeroOut := make(linalg.Vector, v.OutputSize())
+	for i := 0; i < n; i++ {
+		var sample seqtoseq.Sample
+		digitCount := rand.Intn(v.MaxDigits) + 1
+		operands := make([][]int, 2)
+		for j := range operands {
+			for k := 0; k < digitCount; k++ {
+				digit := rand.Intn(v.Base)
+				operands[j] = append(operands[j], digit)
+				inVec := make(linalg.Vector, v.InputSize())
+				inVec[digit] = 1
+				sample.Inputs = append(sample.Inputs, inVec)
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			}
+			delimiter := make(linalg.Vector, v.InputSize())
+			delimiter[v.Base] = 1
+			sample.Inputs = append(sample.Inputs, delimiter)
+			sample.Outputs = append(sample.Outputs, zeroOut)
+		}
+
+		var sum []int
+		var carry int
+		for j, x := range operands[0] {
+			total := x + operands[1][j] + carry
+			sum = append(sum, total%v.Base)
+			carry = total / v.Base
+		}
+		if carry != 0 {
+			sum = append(sum, carry)
+		}
+		sum = append(sum, v.Base)
+
+		for j := 0; j < v.MaxDigits+2; j++ {
+			sample.Inputs = append(sample.Inputs, zeroIn)
+			if j < len(sum) {
+				outVec := make(linalg.Vector, v.OutputSize())
+				outVec[sum[j]] = 1
+				sample.Outputs = append(sample.Outputs, outVec)
+			} else {
+				sample.Outputs = append(sample.Outputs, zeroOut)
+			}
+		}
+		res = append(res, sample)
+	}
+	return res
+}
+
+// Score decodes the model's answers up to the end symbol
+// and measures their similarity to the correct answers,
+// using the edit distance.
+// A score of 1 indicates that every answer is correct.
+func (v *VariableAdditionTask) Score(model Model, batchSize, batchCount int) float64 {
+	return editDistanceTailScore(v, model, batchSize, batchCount, func(s []linalg.Vector) int {
+		var seenBefore bool
+		for i, x := range s {
+			if x[v.Base] == 1 {
+				if seenBefore {
+					return i + 1
+				}
+				seenBefore = true
+			}
+		}
+		panic("no tail found")
+	}, v.Base)
+}